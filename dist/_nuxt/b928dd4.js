@@ -1 +1 @@
-(window.webpackJsonp=window.webpackJsonp||[]).push([[3],{224:function(t,e,r){var content=r(228);content.__esModule&&(content=content.default),"string"==typeof content&&(content=[[t.i,content,""]]),content.locals&&(t.exports=content.locals);(0,r(59).default)("bad8e090",content,!0,{sourceMap:!1})},226:function(t,e,r){t.exports=r.p+"img/logo.6a2913c.svg"},227:function(t,e,r){"use strict";r(224)},228:function(t,e,r){var l=r(58)((function(i){return i[1]}));l.push([t.i,"#main{\n  min-height:calc(100vh - 80px)\n}",""]),t.exports=l},231:function(t,e,r){"use strict";r.r(e);var l=[function(){var t=this,e=t.$createElement,l=t._self._c||e;return l("header",{staticClass:"fixed inset-x-0 h-24 p-5 bg-white shadow-md",attrs:{id:"header"}},[l("div",{staticClass:"grid h-full grid-cols-3",attrs:{id:"header-container"}},[l("img",{staticClass:"self-center h-6 xl:h-10",attrs:{id:"logo",src:r(226),alt:"ie logo"}}),t._v(" "),l("div",{staticClass:"self-center col-span-2 text-sm font-bold text-gray-500 xl:col-span-1 xl:text-3xl title justify-self-end lg:justify-self-center"},[t._v("\n        Cutter Service Status Dashboard\n      ")])])])},function(){var t=this,e=t.$createElement,r=t._self._c||e;return r("div",{staticClass:"grid gap-4 gird-cols-2"},[r("p",{staticClass:"text-2xl uppercase"},[t._v("Green Box - 200/OK = Healthy")]),t._v(" "),r("p",{staticClass:"text-2xl uppercase"},[t._v("Red Box - 502/504 = Unhealthy")]),t._v(" "),r("p",{staticClass:"text-2xl uppercase"},[t._v("PLATFORM = Platform API Service Pod Health")]),t._v(" "),r("p",{staticClass:"text-2xl uppercase"},[t._v("FULFILLMENT = Fulfillment Service Pod Health")]),t._v(" "),r("p",{staticClass:"text-2xl uppercase"},[t._v("CRM = CRM Service Pod Health")]),t._v(" "),r("p",{staticClass:"text-2xl uppercase"},[t._v("STUDY = Study Service Pod Health")]),t._v(" "),r("p",{staticClass:"text-2xl uppercase"},[t._v("PLATFORM-UI = Platform UI Pod Health")]),t._v(" "),r("p",{staticClass:"text-2xl uppercase"},[t._v("STUDY-UI = Study-UI Pod Health")]),t._v(" "),r("p",{staticClass:"text-2xl uppercase"},[t._v("INFRASTRUCTURE = Healthy CPU and Memory Utilization")])])}],n=r(7),c=(r(48),r(60),r(57)),o=r.n(c),d={data:function(){return{ret:null}},mounted:function(){var t=this;return Object(n.a)(regeneratorRuntime.mark((function e(){return regeneratorRuntime.wrap((function(e){for(;;)switch(e.prev=e.next){case 0:setInterval((function(){console.log("interval = 60 seconds")}),6e4),t.getallstatuses();case 2:case"end":return e.stop()}}),e)})))()},methods:{getallstatuses:function(){var t=this;return Object(n.a)(regeneratorRuntime.mark((function e(){var data;return regeneratorRuntime.wrap((function(e){for(;;)switch(e.prev=e.next){case 0:return e.prev=0,e.next=3,o.a.get("https://dashboard.dev.cutter.live/api/v1/get-all-statuses");case 3:data=e.sent,t.ret=data.data,console.log(data),e.next=11;break;case 8:e.prev=8,e.t0=e.catch(0),console.log(e.t0);case 11:case"end":return e.stop()}}),e,null,[[0,8]])})))()}}},v=(r(227),r(46)),component=Object(v.a)(d,(function(){var t=this,e=t.$createElement,r=t._self._c||e;return r("main",{staticClass:"container flex flex-col mx-auto",attrs:{id:"main"}},[t._m(0),t._v(" "),r("div",{staticClass:"grid flex-1 w-full grid-cols-1 grid-rows-4 gap-5 p-5 text-gray-500 lg:grid-cols-4 lg:grid-rows-1 mx-a6to pt-36"},t._l(t.ret,(function(e){return r("div",{key:e.StatusId,staticClass:"relative flex items-center justify-between h-full px-2 py-5 overflow-hidden bg-white rounded-md shadow-md xl:p-5"},[r("div",[r("p",{staticClass:"text-2xl uppercase"},[t._v(t._s(e.service))])]),t._v(" "),r("div",{class:("Ok"===e.status||"200"===e.status||"201"===e.status?"bg-green-800":"bg-red-900")+" text-white absolute right-0 top-0 bottom-0 p-5 font-bold text-2xl"},[t._v("\n        "+t._s(e.status)+"\n      ")])])})),0),t._v(" "),t._m(1)])}),l,!1,null,null,null);e.default=component.exports}}]);
\ No newline at end of file
+(window.webpackJsonp=window.webpackJsonp||[]).push([[3],{224:function(t,e,r){var content=r(228);content.__esModule&&(content=content.default),"string"==typeof content&&(content=[[t.i,content,""]]),content.locals&&(t.exports=content.locals);(0,r(59).default)("bad8e090",content,!0,{sourceMap:!1})},226:function(t,e,r){t.exports=r.p+"img/logo.6a2913c.svg"},227:function(t,e,r){"use strict";r(224)},228:function(t,e,r){var l=r(58)((function(i){return i[1]}));l.push([t.i,"#main{\n  min-height:calc(100vh - 80px)\n}",""]),t.exports=l},231:function(t,e,r){"use strict";r.r(e);var l=[function(){var t=this,e=t.$createElement,l=t._self._c||e;return l("header",{staticClass:"fixed inset-x-0 h-24 p-5 bg-white shadow-md",attrs:{id:"header"}},[l("div",{staticClass:"grid h-full grid-cols-3",attrs:{id:"header-container"}},[l("img",{staticClass:"self-center h-6 xl:h-10",attrs:{id:"logo",src:r(226),alt:"ie logo"}}),t._v(" "),l("div",{staticClass:"self-center col-span-2 text-sm font-bold text-gray-500 xl:col-span-1 xl:text-3xl title justify-self-end lg:justify-self-center"},[t._v("\n        Cutter Service Status Dashboard\n      ")])])])},function(){var t=this,e=t.$createElement,r=t._self._c||e;return r("div",{staticClass:"grid gap-4 gird-cols-2"},[r("p",{staticClass:"text-2xl uppercase"},[t._v("Green Box - 200/OK = Healthy")]),t._v(" "),r("p",{staticClass:"text-2xl uppercase"},[t._v("Red Box - 502/504 = Unhealthy")]),t._v(" "),r("p",{staticClass:"text-2xl uppercase"},[t._v("Yellow Box - Degraded = Up, With Problems")]),t._v(" "),r("p",{staticClass:"text-2xl uppercase"},[t._v("Gray Box - Unknown/No Data = Not Currently Measurable")]),t._v(" "),r("p",{staticClass:"text-2xl uppercase"},[t._v("PLATFORM = Platform API Service Pod Health")]),t._v(" "),r("p",{staticClass:"text-2xl uppercase"},[t._v("FULFILLMENT = Fulfillment Service Pod Health")]),t._v(" "),r("p",{staticClass:"text-2xl uppercase"},[t._v("CRM = CRM Service Pod Health")]),t._v(" "),r("p",{staticClass:"text-2xl uppercase"},[t._v("STUDY = Study Service Pod Health")]),t._v(" "),r("p",{staticClass:"text-2xl uppercase"},[t._v("PLATFORM-UI = Platform UI Pod Health")]),t._v(" "),r("p",{staticClass:"text-2xl uppercase"},[t._v("STUDY-UI = Study-UI Pod Health")]),t._v(" "),r("p",{staticClass:"text-2xl uppercase"},[t._v("INFRASTRUCTURE = Healthy CPU and Memory Utilization")])])}],n=r(7),c=(r(48),r(60),r(57)),o=r.n(c),d={data:function(){return{ret:null}},mounted:function(){var t=this;return Object(n.a)(regeneratorRuntime.mark((function e(){return regeneratorRuntime.wrap((function(e){for(;;)switch(e.prev=e.next){case 0:setInterval((function(){console.log("interval = 60 seconds")}),6e4),t.getallstatuses();case 2:case"end":return e.stop()}}),e)})))()},methods:{getallstatuses:function(){var t=this;return Object(n.a)(regeneratorRuntime.mark((function e(){var data;return regeneratorRuntime.wrap((function(e){for(;;)switch(e.prev=e.next){case 0:return e.prev=0,e.next=3,o.a.get("https://dashboard.dev.cutter.live/api/v1/get-all-statuses");case 3:data=e.sent,t.ret=data.data,console.log(data),e.next=11;break;case 8:e.prev=8,e.t0=e.catch(0),console.log(e.t0);case 11:case"end":return e.stop()}}),e,null,[[0,8]])})))()}}},v=(r(227),r(46)),component=Object(v.a)(d,(function(){var t=this,e=t.$createElement,r=t._self._c||e;return r("main",{staticClass:"container flex flex-col mx-auto",attrs:{id:"main"}},[t._m(0),t._v(" "),r("div",{staticClass:"grid flex-1 w-full grid-cols-1 grid-rows-4 gap-5 p-5 text-gray-500 lg:grid-cols-4 lg:grid-rows-1 mx-a6to pt-36"},t._l(t.ret,(function(e){return r("div",{key:e.StatusId,staticClass:"relative flex items-center justify-between h-full px-2 py-5 overflow-hidden bg-white rounded-md shadow-md xl:p-5"},[r("div",[r("p",{staticClass:"text-2xl uppercase"},[t._v(t._s(e.service))])]),t._v(" "),r("div",{class:("Ok"===e.status||"200"===e.status||"201"===e.status?"bg-green-800":"degraded"===e.status?"bg-yellow-600":"unknown"===e.status||"no data"===e.status?"bg-gray-600":"bg-red-900")+" text-white absolute right-0 top-0 bottom-0 p-5 font-bold text-2xl"},[t._v("\n        "+t._s(e.status)+"\n      ")])])})),0),t._v(" "),t._m(1)])}),l,!1,null,null,null);e.default=component.exports}}]);
\ No newline at end of file
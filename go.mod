@@ -14,8 +14,11 @@ require (
 	github.com/go-chi/chi v4.1.2+incompatible
 	github.com/gocarina/gocsv v0.0.0-20210516172204-ca9e8a8ddea8
 	github.com/kelseyhightower/envconfig v1.4.0
+	github.com/lib/pq v1.7.0
 	github.com/rs/cors v1.7.0
 	go.opencensus.io v0.23.0
+	golang.org/x/net v0.0.0-20210316092652-d523dce5a7f4
 	google.golang.org/api v0.43.0
 	google.golang.org/genproto v0.0.0-20210402141018-6c239bbf2bb1
+	google.golang.org/grpc v1.36.1
 )
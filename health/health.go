@@ -0,0 +1,226 @@
+// Package health serves the /healthcheck response the status dashboard
+// reads. Services register a probe per component and mount the Handler:
+//
+//	h := &health.Handler{ServiceId: "crm-api", Version: version}
+//	h.Register("postgres", "datastore", health.DB(db))
+//	h.RegisterOptional("hibbert", "component", health.HTTP(client, hibbertURL))
+//	router.Handle("/healthcheck", h)
+//
+// The response is application/health+json, see
+// https://tools.ietf.org/html/draft-inadarei-api-health-check.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	Pass = "pass"
+	Warn = "warn"
+	Fail = "fail"
+)
+
+const (
+	defaultTimeout  = 5 * time.Second
+	defaultCacheTTL = 10 * time.Second
+)
+
+// ProbeFunc checks one component and returns an error when it is failing,
+// or an error from Warning when it is only degraded.
+type ProbeFunc func(ctx context.Context) error
+
+type warning struct {
+	err error
+}
+
+func (w *warning) Error() string { return w.err.Error() }
+
+// Warning marks err as degrading its component rather than failing it.
+func Warning(err error) error {
+	return &warning{err: err}
+}
+
+type Response struct {
+	Status    string                    `json:"status"`
+	Version   string                    `json:"version,omitempty"`
+	ReleaseId string                    `json:"releaseId,omitempty"`
+	ServiceId string                    `json:"serviceId,omitempty"`
+	Checks    map[string][]*Observation `json:"checks,omitempty"`
+}
+
+type Observation struct {
+	ComponentType string  `json:"componentType,omitempty"`
+	Status        string  `json:"status"`
+	ObservedValue float64 `json:"observedValue"`
+	ObservedUnit  string  `json:"observedUnit"`
+	Time          string  `json:"time"`
+	Output        string  `json:"output,omitempty"`
+}
+
+type probe struct {
+	name          string
+	componentType string
+	optional      bool
+	fn            ProbeFunc
+}
+
+// Handler runs the registered probes and serves their results. Results
+// are cached for CacheTTL so frequent polling doesn't load dependencies.
+type Handler struct {
+	ServiceId string
+	Version   string
+	ReleaseId string
+
+	// Timeout bounds each probe, CacheTTL how long results are reused.
+	Timeout  time.Duration
+	CacheTTL time.Duration
+
+	mu     sync.Mutex
+	probes []*probe
+	cached *Response
+	expiry time.Time
+}
+
+// Register adds a component whose failure fails the service.
+func (h *Handler) Register(name, componentType string, fn ProbeFunc) {
+	h.register(&probe{name: name, componentType: componentType, fn: fn})
+}
+
+// RegisterOptional adds a component whose failure only degrades the
+// service, e.g. a dependency with a fallback.
+func (h *Handler) RegisterOptional(name, componentType string, fn ProbeFunc) {
+	h.register(&probe{name: name, componentType: componentType, optional: true, fn: fn})
+}
+
+func (h *Handler) register(p *probe) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.probes = append(h.probes, p)
+	h.cached = nil
+}
+
+// Check returns the service's health, running the probes unless a cached
+// response is still fresh.
+func (h *Handler) Check(ctx context.Context) *Response {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.cached != nil && time.Now().Before(h.expiry) {
+		return h.cached
+	}
+
+	ttl := h.CacheTTL
+	if ttl <= 0 {
+		ttl = defaultCacheTTL
+	}
+
+	h.cached = h.run(ctx)
+	h.expiry = time.Now().Add(ttl)
+
+	return h.cached
+}
+
+func (h *Handler) run(ctx context.Context) *Response {
+	timeout := h.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	res := &Response{
+		Status:    Pass,
+		Version:   h.Version,
+		ReleaseId: h.ReleaseId,
+		ServiceId: h.ServiceId,
+		Checks:    map[string][]*Observation{},
+	}
+
+	observations := make([]*Observation, len(h.probes))
+	var wg sync.WaitGroup
+	for i, p := range h.probes {
+		wg.Add(1)
+		go func(i int, p *probe) {
+			defer wg.Done()
+			observations[i] = runProbe(ctx, p, timeout)
+		}(i, p)
+	}
+	wg.Wait()
+
+	for i, p := range h.probes {
+		o := observations[i]
+		res.Checks[p.name] = append(res.Checks[p.name], o)
+
+		status := o.Status
+		if status == Fail && p.optional {
+			status = Warn
+		}
+		res.Status = worse(res.Status, status)
+	}
+
+	return res
+}
+
+func runProbe(ctx context.Context, p *probe, timeout time.Duration) *Observation {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	errc := make(chan error, 1)
+	go func() {
+		errc <- p.fn(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-errc:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	o := &Observation{
+		ComponentType: p.componentType,
+		Status:        Pass,
+		ObservedValue: float64(time.Since(start).Milliseconds()),
+		ObservedUnit:  "ms",
+		Time:          start.UTC().Format(time.RFC3339),
+	}
+
+	var w *warning
+	switch {
+	case errors.As(err, &w):
+		o.Status = Warn
+		o.Output = err.Error()
+	case err != nil:
+		o.Status = Fail
+		o.Output = err.Error()
+	}
+
+	return o
+}
+
+func worse(a, b string) string {
+	rank := map[string]int{Pass: 0, Warn: 1, Fail: 2}
+	if rank[b] > rank[a] {
+		return b
+	}
+	return a
+}
+
+// ServeHTTP writes the health response, with a 503 when the service fails
+// so plain HTTP probes see it too.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	res := h.Check(r.Context())
+
+	w.Header().Set("Content-Type", "application/health+json")
+	w.Header().Set("Cache-Control", "no-store")
+	if res.Status == Fail {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	json.NewEncoder(w).Encode(res)
+}
@@ -0,0 +1,48 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// DB pings db.
+func DB(db *sql.DB) ProbeFunc {
+	return func(ctx context.Context) error {
+		return db.PingContext(ctx)
+	}
+}
+
+// HTTP gets url with client and fails on anything but a 2xx. A 429 only
+// warns, the dependency is up but we are being throttled.
+func HTTP(client *http.Client, url string) ProbeFunc {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		if err != nil {
+			return err
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 1<<16))
+
+		switch {
+		case resp.StatusCode == http.StatusTooManyRequests:
+			return Warning(fmt.Errorf("%s: %s", url, resp.Status))
+		case resp.StatusCode < 200 || resp.StatusCode >= 300:
+			return fmt.Errorf("%s: %s", url, resp.Status)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,199 @@
+package healthchecks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"time"
+)
+
+// Statuses reported by configured checks. Operational is "Ok" because that
+// is what the dashboard and the outage log already treat as healthy.
+const (
+	StatusOperational = "Ok"
+	StatusDegraded    = "degraded"
+	StatusDown        = "down"
+	StatusUnknown     = "unknown"
+)
+
+const (
+	defaultInterval = 60 * time.Second
+	defaultTimeout  = 10 * time.Second
+)
+
+type Result struct {
+	Status    string             `json:"status"`
+	Message   string             `json:"message,omitempty"`
+	Latency   time.Duration      `json:"latency"`
+	Metrics   map[string]float64 `json:"metrics,omitempty"`
+	CheckedAt time.Time          `json:"checked_at"`
+
+	Components []*Component `json:"components,omitempty"`
+	Version    *Version     `json:"version,omitempty"`
+}
+
+// Checker is implemented by each check type.
+type Checker interface {
+	Check(ctx context.Context) *Result
+}
+
+// Check is a Checker scheduled under the service name it reports as.
+type Check struct {
+	Name     string
+	Type     string
+	Interval time.Duration
+	Timeout  time.Duration
+	Checker  Checker
+
+	// DisplayName and Group are how the dashboard labels and groups the
+	// service, optional.
+	DisplayName string
+	Group       string
+}
+
+func (c *Check) Run(ctx context.Context) *Result {
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	res := c.Checker.Check(ctx)
+	if res.Latency == 0 {
+		res.Latency = time.Since(start)
+	}
+	res.CheckedAt = start.UTC()
+
+	return res
+}
+
+// Duration reads "30s" style durations from the checks file.
+type Duration struct {
+	time.Duration
+}
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	d.Duration = v
+
+	return nil
+}
+
+// ChecksConfig is the checks file, see LoadChecks.
+type ChecksConfig struct {
+	// DataSources are databases checks can refer to by name.
+	DataSources map[string]*DataSource `json:"datasources"`
+
+	// Kubernetes is the API server kubernetes checks read from.
+	Kubernetes *KubeConfig `json:"kubernetes"`
+
+	Checks []*CheckDefinition `json:"checks"`
+}
+
+type CheckDefinition struct {
+	Name        string          `json:"name"`
+	DisplayName string          `json:"display_name"`
+	Group       string          `json:"group"`
+	Type        string          `json:"type"`
+	Interval    Duration        `json:"interval"`
+	Timeout     Duration        `json:"timeout"`
+	Config      json.RawMessage `json:"config"`
+}
+
+// Factory builds a Checker from the "config" object of a check definition.
+type Factory func(raw json.RawMessage, cfg *ChecksConfig) (Checker, error)
+
+var checkTypes = map[string]Factory{}
+
+func registerCheckType(name string, f Factory) {
+	checkTypes[name] = f
+}
+
+// LoadChecks reads the checks file at path, a JSON document like
+//
+//	{
+//		"datasources": {"orders": {"dsn": "postgres://..."}},
+//		"checks": [{"name": "cutter-live-cert", "type": "tls", "interval": "1h", "config": {"host": "cutter.live"}}]
+//	}
+func LoadChecks(path string) ([]*Check, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &ChecksConfig{}
+	if err := json.Unmarshal(b, cfg); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+
+	return cfg.Build()
+}
+
+func (cfg *ChecksConfig) Build() ([]*Check, error) {
+	seen := map[string]bool{}
+	ret := []*Check{}
+
+	for _, def := range cfg.Checks {
+		if def.Name == "" {
+			return nil, fmt.Errorf("check of type %q has no name", def.Type)
+		}
+		if seen[def.Name] {
+			return nil, fmt.Errorf("check %s: duplicate name", def.Name)
+		}
+		seen[def.Name] = true
+
+		factory, ok := checkTypes[def.Type]
+		if !ok {
+			return nil, fmt.Errorf("check %s: unknown type %q", def.Name, def.Type)
+		}
+
+		checker, err := factory(def.Config, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("check %s: %v", def.Name, err)
+		}
+
+		c := &Check{
+			Name:        def.Name,
+			Type:        def.Type,
+			Interval:    def.Interval.Duration,
+			Timeout:     def.Timeout.Duration,
+			Checker:     checker,
+			DisplayName: def.DisplayName,
+			Group:       def.Group,
+		}
+		if c.Interval <= 0 {
+			c.Interval = defaultInterval
+		}
+		if c.Timeout <= 0 {
+			c.Timeout = defaultTimeout
+			if _, ok := checker.(*EmailCheck); ok {
+				c.Timeout = defaultEmailTimeout
+			}
+		}
+
+		ret = append(ret, c)
+	}
+
+	return ret, nil
+}
+
+// decodeConfig unmarshals a check's "config" object, rejecting unknown
+// fields so typos don't silently fall back to defaults.
+func decodeConfig(raw json.RawMessage, v interface{}) error {
+	if len(raw) == 0 {
+		raw = json.RawMessage("{}")
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+
+	return dec.Decode(v)
+}
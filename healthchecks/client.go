@@ -8,12 +8,41 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/IdeaEvolver/cutter-pkg/client"
 )
 
+// StatusMisconfigured is reported for a vendor that rejected our
+// credentials. The vendor is reachable, our configuration is broken.
+const StatusMisconfigured = "misconfigured"
+
+// Credential health of a vendor integration as seen by its last probe.
+const (
+	CredentialsValid   = "valid"
+	CredentialsInvalid = "invalid"
+	CredentialsUnknown = "unknown"
+)
+
 type ServiceResponse struct {
 	Status string `json:"status"`
+
+	// Detailed health responses break the status down by component, see
+	// Components.
+	Checks             map[string][]*HealthObservation `json:"checks,omitempty"`
+	ActuatorComponents map[string]*actuatorComponent   `json:"components,omitempty"`
+
+	// Build metadata, see BuildInfo.
+	Version   string `json:"version,omitempty"`
+	ReleaseId string `json:"releaseId,omitempty"`
+	Commit    string `json:"commit,omitempty"`
+	Build     string `json:"build,omitempty"`
+	header    http.Header
+
+	// Credentials and Detail are only set by vendor probes.
+	Credentials string `json:"-"`
+	Detail      string `json:"-"`
 }
 
 type ExternalConfig struct {
@@ -27,6 +56,20 @@ type ExternalConfig struct {
 	ClientSecret    string
 	AZCRMUrl        string
 	XAppId          string
+
+	// Teams to notify when an integration's credentials are rejected.
+	HibbertOwner string
+	StripeOwner  string
+	AZCRMOwner   string
+
+	// HibbertTokenTTL is how long a Hibbert token is reused, Hibbert
+	// doesn't tell us when its tokens expire.
+	HibbertTokenTTL time.Duration
+
+	// Requests per hour each vendor probe may make, 0 for no limit.
+	HibbertBudget int
+	StripeBudget  int
+	AZCRMBudget   int
 }
 
 type Client struct {
@@ -38,6 +81,9 @@ type Client struct {
 	Study       string
 
 	ExternalConfig ExternalConfig
+
+	mu      sync.Mutex
+	vendors map[string]*vendor
 }
 
 type HttpClient interface {
@@ -58,24 +104,96 @@ func (c *Client) do(ctx context.Context, req *client.Request, ret interface{}) e
 	return nil
 }
 
-func (c *Client) doExternal(ctx context.Context, req *http.Request) string {
-	internalClient := &http.Client{}
+// doHealth gets a service's own healthcheck, keeping the response headers
+// for the version they may carry.
+func (c *Client) doHealth(ctx context.Context, req *client.Request) (*ServiceResponse, error) {
+	res, err := c.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	status := &ServiceResponse{}
+	if err := json.NewDecoder(res.Body).Decode(status); err != nil {
+		return nil, err
+	}
+	status.header = res.Header
+
+	return status, nil
+}
+
+// doExternal sends req on behalf of v and classifies the response. keep is
+// given successful responses, e.g. to cache the token they carry. While the
+// vendor can't be asked the last known status is reported instead.
+func (c *Client) doExternal(ctx context.Context, v *vendor, req *http.Request, keep func(*http.Response, *ServiceResponse)) *ServiceResponse {
+	resp, err := v.do(req)
+	if err == errThrottled {
+		return v.lastStatus(&ServiceResponse{Status: err.Error(), Credentials: CredentialsUnknown, Detail: err.Error()})
+	}
+	if err != nil {
+		return v.remember(&ServiceResponse{Status: err.Error(), Credentials: CredentialsUnknown, Detail: err.Error()})
+	}
+	defer resp.Body.Close()
+
+	status := &ServiceResponse{
+		Status:      strings.TrimSpace(resp.Status),
+		Credentials: CredentialsValid,
+		Detail:      strings.TrimSpace(resp.Status),
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		status.Credentials = CredentialsUnknown
+		return v.lastStatus(status)
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		status.Status = StatusMisconfigured
+		status.Credentials = CredentialsInvalid
+	case resp.StatusCode >= 500:
+		// the vendor is failing before it gets to look at our credentials
+		status.Credentials = CredentialsUnknown
+	case resp.StatusCode < 300 && keep != nil:
+		keep(resp, status)
+	}
+
+	return v.remember(status)
+}
+
+// doReachable checks a vendor whose token is still cached without spending
+// an auth call: an unauthenticated HEAD that gets any answer short of a
+// server error means the vendor is up and the cached auth result stands.
+func (c *Client) doReachable(ctx context.Context, v *vendor, url string) *ServiceResponse {
+	req, _ := http.NewRequestWithContext(ctx, "HEAD", url, nil)
+
+	resp, err := v.do(req)
+	if err == errThrottled {
+		return v.lastStatus(&ServiceResponse{Status: err.Error(), Credentials: CredentialsUnknown, Detail: err.Error()})
+	}
+	if err != nil {
+		return v.remember(&ServiceResponse{Status: err.Error(), Credentials: CredentialsUnknown, Detail: err.Error()})
+	}
+	resp.Body.Close()
+
+	status := &ServiceResponse{
+		Status:      strings.TrimSpace(resp.Status),
+		Credentials: CredentialsUnknown,
+		Detail:      strings.TrimSpace(resp.Status),
+	}
 
-	resp, _ := internalClient.Do(req)
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return v.lastStatus(status)
+	case resp.StatusCode >= 500:
+		return v.remember(status)
+	}
 
-	return strings.TrimSpace(resp.Status)
+	return v.remember(v.cachedAuthStatus())
 }
 
 func (c *Client) PlatformStatus(ctx context.Context) (*ServiceResponse, error) {
 	url := fmt.Sprintf("%s/healthcheck", c.Platform)
 	req, _ := client.NewRequestWithContext(ctx, "GET", url, nil)
 
-	status := &ServiceResponse{}
-	if err := c.do(ctx, req, &status); err != nil {
-		return nil, err
-	}
-
-	return status, nil
+	return c.doHealth(ctx, req)
 }
 
 func (c *Client) PlatformUIStatus(ctx context.Context) (*ServiceResponse, error) {
@@ -94,36 +212,21 @@ func (c *Client) FulfillmentStatus(ctx context.Context) (*ServiceResponse, error
 	url := fmt.Sprintf("%s/healthcheck", c.Fulfillment)
 	req, _ := client.NewRequestWithContext(ctx, "GET", url, nil)
 
-	status := &ServiceResponse{}
-	if err := c.do(ctx, req, &status); err != nil {
-		return nil, err
-	}
-
-	return status, nil
+	return c.doHealth(ctx, req)
 }
 
 func (c *Client) CrmStatus(ctx context.Context) (*ServiceResponse, error) {
 	url := fmt.Sprintf("%s/healthcheck", c.Crm)
 	req, _ := client.NewRequestWithContext(ctx, "GET", url, nil)
 
-	status := &ServiceResponse{}
-	if err := c.do(ctx, req, &status); err != nil {
-		return nil, err
-	}
-
-	return status, nil
+	return c.doHealth(ctx, req)
 }
 
 func (c *Client) StudyStatus(ctx context.Context) (*ServiceResponse, error) {
 	url := fmt.Sprintf("%s/healthcheck", c.Study)
 	req, _ := client.NewRequestWithContext(ctx, "GET", url, nil)
 
-	status := &ServiceResponse{}
-	if err := c.do(ctx, req, &status); err != nil {
-		return nil, err
-	}
-
-	return status, nil
+	return c.doHealth(ctx, req)
 }
 
 func (c *Client) StudyUIStatus(ctx context.Context) (*ServiceResponse, error) {
@@ -144,6 +247,12 @@ type hibbertResponse struct {
 
 func (c *Client) HibbertStatus(ctx context.Context) (*ServiceResponse, error) {
 	url := c.ExternalConfig.HibbertEndpoint
+	v := c.vendor("hibbert", c.ExternalConfig.HibbertBudget)
+
+	if _, ok := v.cachedToken(time.Now()); ok {
+		return c.doReachable(ctx, v, url), nil
+	}
+
 	body := struct {
 		AppId    string `json:"appId"`
 		Username string `json:"username"`
@@ -159,44 +268,54 @@ func (c *Client) HibbertStatus(ctx context.Context) (*ServiceResponse, error) {
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("Accept", "application/json")
 
-	httpStatus := c.doExternal(ctx, req)
-
-	status := &ServiceResponse{Status: httpStatus}
-
-	return status, nil
+	return c.doExternal(ctx, v, req, func(resp *http.Response, status *ServiceResponse) {
+		token := &hibbertResponse{}
+		if err := json.NewDecoder(resp.Body).Decode(token); err == nil && token.Token != "" {
+			v.setToken(token.Token, c.ExternalConfig.HibbertTokenTTL, status)
+		}
+	}), nil
 }
 
 func (c *Client) StripeStatus(ctx context.Context) (*ServiceResponse, error) {
 	url := c.ExternalConfig.StripeEndpoint
+	v := c.vendor("stripe", c.ExternalConfig.StripeBudget)
 
-	req, _ := http.NewRequest("POST", url, nil)
+	req, _ := http.NewRequestWithContext(ctx, "POST", url, nil)
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("Accept", "application/json")
 	req.Header.Add("Authorization", "Bearer "+c.ExternalConfig.StripeKey)
 
-	httpStatus := c.doExternal(ctx, req)
-
-	status := &ServiceResponse{Status: httpStatus}
+	return c.doExternal(ctx, v, req, nil), nil
+}
 
-	return status, nil
+type azcrmResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
 }
 
 func (c *Client) AZCRMStatus(ctx context.Context) (*ServiceResponse, error) {
+	endpoint := c.ExternalConfig.AZCRMUrl + "/csdcidentity/oauth/token"
+	v := c.vendor("az_crm", c.ExternalConfig.AZCRMBudget)
+
+	if _, ok := v.cachedToken(time.Now()); ok {
+		return c.doReachable(ctx, v, endpoint), nil
+	}
+
 	q := url.Values{}
 	q.Add("grant_type", "client_credentials")
 	q.Add("scope", "openid")
 	q.Add("client_id", c.ExternalConfig.ClientId)
 	q.Add("client_secret", c.ExternalConfig.ClientSecret)
 
-	url := "https://identityapiqa.a.astrazeneca.com" + "/csdcidentity/oauth/token" + "?" + q.Encode()
-
-	req, _ := http.NewRequestWithContext(ctx, "POST", url, nil)
+	req, _ := http.NewRequestWithContext(ctx, "POST", endpoint+"?"+q.Encode(), nil)
 	req.Header.Add("accept", "application/json")
 	req.Header.Add("content-type", "application/json")
 	req.Header.Add("X-App-Id", c.ExternalConfig.XAppId)
 
-	httpStatus := c.doExternal(ctx, req)
-	status := &ServiceResponse{Status: httpStatus}
-
-	return status, nil
+	return c.doExternal(ctx, v, req, func(resp *http.Response, status *ServiceResponse) {
+		token := &azcrmResponse{}
+		if err := json.NewDecoder(resp.Body).Decode(token); err == nil && token.AccessToken != "" {
+			v.setToken(token.AccessToken, time.Duration(token.ExpiresIn)*time.Second, status)
+		}
+	}), nil
 }
@@ -0,0 +1,206 @@
+package healthchecks
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Annotations on a Service or Ingress that opt it in to discovery. Only
+// health-path is required.
+const (
+	AnnotationHealthPath  = "status-dashboard/health-path"
+	AnnotationDisplayName = "status-dashboard/name"
+	AnnotationGroup       = "status-dashboard/group"
+	AnnotationInterval    = "status-dashboard/interval"
+
+	// AnnotationPort picks a Service port by name or number, the first by
+	// default. AnnotationScheme is http or https, defaulting to http for
+	// Services and to https for Ingresses with TLS.
+	AnnotationPort   = "status-dashboard/port"
+	AnnotationScheme = "status-dashboard/scheme"
+)
+
+// Discovery finds Services and Ingresses carrying the annotations above and
+// builds an HTTP check for each.
+type Discovery struct {
+	Client KubeClient
+
+	// Namespaces to look in, all of them when empty.
+	Namespaces []string
+
+	// Kinds is "services", "ingresses" or both.
+	Kinds []string
+
+	// TLS is used by the discovered checks.
+	TLS *TLSConfig
+}
+
+// Discover lists the annotated objects. Checks are named after the object,
+// <namespace>-<name> for Services and <namespace>-<name>-ingress for
+// Ingresses. Objects with bad annotations are skipped and reported in the
+// returned errors, a failure to list at all is the error.
+func (d *Discovery) Discover(ctx context.Context) ([]*Check, []error, error) {
+	namespaces := d.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{""}
+	}
+
+	checks := []*Check{}
+	problems := []error{}
+	add := func(meta ObjectMeta, name, url string) {
+		c, err := d.check(meta, name, url)
+		if err != nil {
+			problems = append(problems, fmt.Errorf("%s/%s: %v", meta.Namespace, meta.Name, err))
+			return
+		}
+		checks = append(checks, c)
+	}
+
+	for _, kind := range d.Kinds {
+		for _, ns := range namespaces {
+			switch kind {
+			case "services":
+				services, err := d.Client.Services(ctx, ns, "")
+				if err != nil {
+					return nil, nil, err
+				}
+				for _, s := range services {
+					if _, ok := s.Metadata.Annotations[AnnotationHealthPath]; !ok {
+						continue
+					}
+					base, err := serviceURL(s)
+					if err != nil {
+						problems = append(problems, fmt.Errorf("%s/%s: %v", s.Metadata.Namespace, s.Metadata.Name, err))
+						continue
+					}
+					add(s.Metadata, s.Metadata.Namespace+"-"+s.Metadata.Name, base)
+				}
+
+			case "ingresses":
+				ingresses, err := d.Client.Ingresses(ctx, ns, "")
+				if err != nil {
+					return nil, nil, err
+				}
+				for _, i := range ingresses {
+					if _, ok := i.Metadata.Annotations[AnnotationHealthPath]; !ok {
+						continue
+					}
+					base, err := ingressURL(i)
+					if err != nil {
+						problems = append(problems, fmt.Errorf("%s/%s: %v", i.Metadata.Namespace, i.Metadata.Name, err))
+						continue
+					}
+					add(i.Metadata, i.Metadata.Namespace+"-"+i.Metadata.Name+"-ingress", base)
+				}
+
+			default:
+				return nil, nil, fmt.Errorf("unknown discovery kind %q", kind)
+			}
+		}
+	}
+
+	return checks, problems, nil
+}
+
+func (d *Discovery) check(meta ObjectMeta, name, base string) (*Check, error) {
+	path := meta.Annotations[AnnotationHealthPath]
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	interval := defaultInterval
+	if v, ok := meta.Annotations[AnnotationInterval]; ok {
+		var err error
+		if interval, err = time.ParseDuration(v); err != nil || interval <= 0 {
+			return nil, fmt.Errorf("bad %s %q", AnnotationInterval, v)
+		}
+	}
+
+	checker := &HTTPCheck{URL: base + path, Method: "GET", TLS: d.TLS}
+	if err := checker.init(); err != nil {
+		return nil, err
+	}
+
+	return &Check{
+		Name:        name,
+		Type:        "http",
+		Interval:    interval,
+		Timeout:     defaultTimeout,
+		Checker:     checker,
+		DisplayName: meta.Annotations[AnnotationDisplayName],
+		Group:       meta.Annotations[AnnotationGroup],
+	}, nil
+}
+
+// serviceURL is the Service's cluster DNS name and port.
+func serviceURL(s *Service) (string, error) {
+	if len(s.Spec.Ports) == 0 {
+		return "", fmt.Errorf("service has no ports")
+	}
+
+	port := s.Spec.Ports[0].Port
+	if want, ok := s.Metadata.Annotations[AnnotationPort]; ok {
+		port = 0
+		for _, p := range s.Spec.Ports {
+			if p.Name == want || strconv.Itoa(p.Port) == want {
+				port = p.Port
+			}
+		}
+		if port == 0 {
+			return "", fmt.Errorf("no port %q", want)
+		}
+	}
+
+	scheme, err := annotatedScheme(s.Metadata, "http")
+	if err != nil {
+		return "", err
+	}
+
+	host := s.Metadata.Name + "." + s.Metadata.Namespace + ".svc.cluster.local"
+	return scheme + "://" + net.JoinHostPort(host, strconv.Itoa(port)), nil
+}
+
+// ingressURL is the Ingress's first host.
+func ingressURL(i *Ingress) (string, error) {
+	host := ""
+	for _, r := range i.Spec.Rules {
+		if r.Host != "" {
+			host = r.Host
+			break
+		}
+	}
+	if host == "" {
+		return "", fmt.Errorf("ingress has no host")
+	}
+
+	scheme := "http"
+	for _, t := range i.Spec.TLS {
+		for _, h := range t.Hosts {
+			if h == host {
+				scheme = "https"
+			}
+		}
+	}
+
+	scheme, err := annotatedScheme(i.Metadata, scheme)
+	if err != nil {
+		return "", err
+	}
+
+	return scheme + "://" + host, nil
+}
+
+func annotatedScheme(meta ObjectMeta, def string) (string, error) {
+	scheme, ok := meta.Annotations[AnnotationScheme]
+	if !ok {
+		return def, nil
+	}
+	if scheme != "http" && scheme != "https" {
+		return "", fmt.Errorf("bad %s %q", AnnotationScheme, scheme)
+	}
+	return scheme, nil
+}
@@ -0,0 +1,195 @@
+package healthchecks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+func init() {
+	registerCheckType("dns", newDNSCheck)
+}
+
+// DNSCheck resolves Host, against Resolver when set, and asserts on the
+// answers for each of Records.
+type DNSCheck struct {
+	Host string `json:"host"`
+
+	// Resolver is a host:port nameserver, the system resolver when empty.
+	Resolver string `json:"resolver"`
+
+	Records []*DNSRecord `json:"records"`
+
+	// AlertOnChange reports the check degraded when the answers for a
+	// record without expected values differ from the previous run. Off by
+	// default, round robin and geo DNS rotate their answers.
+	AlertOnChange bool `json:"alert_on_change"`
+
+	resolver *net.Resolver
+
+	mu       sync.Mutex
+	previous map[string][]string
+}
+
+type DNSRecord struct {
+	// Type is one of A, AAAA, CNAME or TXT.
+	Type string `json:"type"`
+
+	// Values the answer must match exactly, in any order.
+	Values []string `json:"values"`
+}
+
+func newDNSCheck(raw json.RawMessage, cfg *ChecksConfig) (Checker, error) {
+	c := &DNSCheck{}
+	if err := decodeConfig(raw, c); err != nil {
+		return nil, err
+	}
+	if c.Host == "" {
+		return nil, errors.New("host is required")
+	}
+	if len(c.Records) == 0 {
+		c.Records = []*DNSRecord{{Type: "A"}}
+	}
+	for _, r := range c.Records {
+		r.Type = strings.ToUpper(r.Type)
+		switch r.Type {
+		case "A", "AAAA", "CNAME", "TXT":
+		default:
+			return nil, fmt.Errorf("unsupported record type %q", r.Type)
+		}
+		r.Values = normalizeAnswers(r.Type, r.Values)
+	}
+
+	c.resolver = net.DefaultResolver
+	if c.Resolver != "" {
+		if _, _, err := net.SplitHostPort(c.Resolver); err != nil {
+			c.Resolver = net.JoinHostPort(c.Resolver, "53")
+		}
+		c.resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+				d := &net.Dialer{}
+				return d.DialContext(ctx, network, c.Resolver)
+			},
+		}
+	}
+	c.previous = map[string][]string{}
+
+	return c, nil
+}
+
+func (c *DNSCheck) Check(ctx context.Context) *Result {
+	res := &Result{Status: StatusOperational, Metrics: map[string]float64{}}
+	problems := []string{}
+	changes := []string{}
+
+	start := time.Now()
+	for _, r := range c.Records {
+		answers, err := c.lookup(ctx, r.Type)
+		if err != nil {
+			res.Status = StatusDown
+			problems = append(problems, fmt.Sprintf("%s: %v", r.Type, err))
+			continue
+		}
+
+		if len(r.Values) > 0 {
+			if !equalAnswers(answers, r.Values) {
+				res.Status = StatusDown
+				problems = append(problems, fmt.Sprintf("%s: got %s, want %s", r.Type, strings.Join(answers, ","), strings.Join(r.Values, ",")))
+			}
+			continue
+		}
+
+		if prev, changed := c.remember(r.Type, answers); changed && c.AlertOnChange {
+			changes = append(changes, fmt.Sprintf("%s changed from %s to %s", r.Type, strings.Join(prev, ","), strings.Join(answers, ",")))
+		}
+	}
+	res.Metrics["resolution_ms"] = float64(time.Since(start).Milliseconds())
+
+	if res.Status == StatusOperational && len(changes) > 0 {
+		res.Status = StatusDegraded
+	}
+	res.Message = strings.Join(append(problems, changes...), "; ")
+
+	return res
+}
+
+func (c *DNSCheck) lookup(ctx context.Context, typ string) ([]string, error) {
+	var answers []string
+
+	switch typ {
+	case "A", "AAAA":
+		addrs, err := c.resolver.LookupIPAddr(ctx, c.Host)
+		if err != nil {
+			return nil, err
+		}
+		for _, addr := range addrs {
+			if (addr.IP.To4() != nil) == (typ == "A") {
+				answers = append(answers, addr.IP.String())
+			}
+		}
+		if len(answers) == 0 {
+			return nil, fmt.Errorf("no %s records for %s", typ, c.Host)
+		}
+	case "CNAME":
+		cname, err := c.resolver.LookupCNAME(ctx, c.Host)
+		if err != nil {
+			return nil, err
+		}
+		answers = []string{cname}
+	case "TXT":
+		txt, err := c.resolver.LookupTXT(ctx, c.Host)
+		if err != nil {
+			return nil, err
+		}
+		answers = txt
+	}
+
+	return normalizeAnswers(typ, answers), nil
+}
+
+// remember stores the answers for typ and reports the previous ones if
+// they differ. The first run only sets the baseline.
+func (c *DNSCheck) remember(typ string, answers []string) ([]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	prev, seen := c.previous[typ]
+	c.previous[typ] = answers
+
+	return prev, seen && !equalAnswers(prev, answers)
+}
+
+func normalizeAnswers(typ string, answers []string) []string {
+	ret := make([]string, 0, len(answers))
+	for _, a := range answers {
+		if typ == "CNAME" {
+			a = strings.ToLower(strings.TrimSuffix(a, "."))
+		}
+		if ip := net.ParseIP(a); ip != nil {
+			a = ip.String()
+		}
+		ret = append(ret, a)
+	}
+	sort.Strings(ret)
+
+	return ret
+}
+
+func equalAnswers(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,282 @@
+package healthchecks
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+func init() {
+	registerCheckType("email", newEmailCheck)
+}
+
+// the header tagging check messages, also searched for in the mailbox
+const emailCheckHeader = "X-Status-Check"
+
+// defaultEmailTimeout replaces defaultTimeout for email checks, delivery
+// routinely taking longer than seconds.
+const defaultEmailTimeout = 5 * time.Minute
+
+// EmailCheck sends a uniquely tagged message through SMTP and polls
+// Mailbox over IMAP or POP3 until it arrives, deleting it once found.
+type EmailCheck struct {
+	SMTP    *MailServer `json:"smtp"`
+	Mailbox *MailServer `json:"mailbox"`
+	From    string      `json:"from"`
+	To      string      `json:"to"`
+
+	PollInterval Duration `json:"poll_interval"`
+
+	// Delivery is end-to-end latency in seconds.
+	Delivery *Threshold `json:"delivery_seconds"`
+}
+
+// MailServer is an SMTP, IMAP or POP3 server. Security is "tls" for TLS
+// from the start, "starttls" to upgrade (SMTP only) or "none".
+type MailServer struct {
+	Address  string     `json:"address"`
+	Protocol string     `json:"protocol"`
+	Username string     `json:"username"`
+	Password string     `json:"password"`
+	Security string     `json:"security"`
+	TLS      *TLSConfig `json:"tls"`
+
+	tlsConfig *tls.Config
+}
+
+func (m *MailServer) init(name string, protocols ...string) error {
+	if m == nil {
+		return fmt.Errorf("%s is required", name)
+	}
+
+	host, _, err := net.SplitHostPort(m.Address)
+	if err != nil {
+		return fmt.Errorf("%s: address must be host:port: %v", name, err)
+	}
+
+	ok := false
+	for _, p := range protocols {
+		ok = ok || m.Protocol == p
+	}
+	if !ok {
+		return fmt.Errorf("%s: protocol must be one of %s", name, strings.Join(protocols, ", "))
+	}
+
+	switch m.Security {
+	case "tls", "none":
+	case "starttls":
+		if m.Protocol != "smtp" {
+			return fmt.Errorf("%s: starttls is only supported for smtp", name)
+		}
+	default:
+		return fmt.Errorf("%s: security must be tls, starttls or none", name)
+	}
+
+	m.tlsConfig, err = m.TLS.Build()
+	if err != nil {
+		return fmt.Errorf("%s: %v", name, err)
+	}
+	if m.tlsConfig.ServerName == "" {
+		m.tlsConfig.ServerName = host
+	}
+
+	return nil
+}
+
+func (m *MailServer) dial(ctx context.Context) (net.Conn, error) {
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", m.Address)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	if m.Security != "tls" {
+		return conn, nil
+	}
+
+	tlsConn := tls.Client(conn, m.tlsConfig)
+	if err := tlsConn.Handshake(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return tlsConn, nil
+}
+
+func newEmailCheck(raw json.RawMessage, cfg *ChecksConfig) (Checker, error) {
+	c := &EmailCheck{
+		SMTP:         &MailServer{Protocol: "smtp", Security: "starttls"},
+		Mailbox:      &MailServer{Protocol: "imap", Security: "tls"},
+		PollInterval: Duration{10 * time.Second},
+	}
+	if err := decodeConfig(raw, c); err != nil {
+		return nil, err
+	}
+	if c.From == "" || c.To == "" {
+		return nil, errors.New("from and to are required")
+	}
+	if c.PollInterval.Duration <= 0 {
+		return nil, errors.New("poll_interval must be positive")
+	}
+	if err := c.SMTP.init("smtp", "smtp"); err != nil {
+		return nil, err
+	}
+	if err := c.Mailbox.init("mailbox", "imap", "pop3"); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *EmailCheck) Check(ctx context.Context) *Result {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return &Result{Status: StatusUnknown, Message: err.Error()}
+	}
+	token := hex.EncodeToString(b)
+
+	start := time.Now()
+	if err := c.send(ctx, token); err != nil {
+		return &Result{Status: StatusDown, Message: "send: " + err.Error()}
+	}
+	metrics := map[string]float64{"send_ms": float64(time.Since(start).Milliseconds())}
+
+	ticker := time.NewTicker(c.PollInterval.Duration)
+	defer ticker.Stop()
+
+	var lastErr, cleanupErr error
+	for {
+		found, err := c.find(ctx, token)
+		if found {
+			cleanupErr = err
+			break
+		}
+		// a poll cut short by the timeout would hide why earlier ones failed
+		if err != nil && !expired(ctx) {
+			lastErr = err
+		}
+
+		select {
+		case <-ctx.Done():
+			msg := fmt.Sprintf("message not delivered after %s", time.Since(start).Round(time.Second))
+			if lastErr != nil {
+				msg += ", last mailbox error: " + lastErr.Error()
+			}
+			return &Result{Status: StatusDown, Message: msg, Metrics: metrics}
+		case <-ticker.C:
+		}
+	}
+
+	delivery := time.Since(start).Seconds()
+	metrics["delivery_seconds"] = delivery
+
+	res := &Result{
+		Status:  c.Delivery.Evaluate(delivery),
+		Message: fmt.Sprintf("delivered in %.1fs", delivery),
+		Metrics: metrics,
+	}
+	if res.Status != StatusOperational {
+		res.Message = c.Delivery.describe("delivery_seconds", delivery)
+	}
+
+	// left behind, check messages would pile up in the mailbox
+	if cleanupErr != nil {
+		res.Status = worse(res.Status, StatusDegraded)
+		res.Message += ", but it was not deleted: " + cleanupErr.Error()
+	}
+
+	return res
+}
+
+// expired is whether ctx is done, or its deadline has passed and it soon
+// will be.
+func expired(ctx context.Context) bool {
+	deadline, ok := ctx.Deadline()
+	return ctx.Err() != nil || ok && !time.Now().Before(deadline)
+}
+
+func (c *EmailCheck) send(ctx context.Context, token string) error {
+	conn, err := c.SMTP.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client, err := smtp.NewClient(conn, c.SMTP.tlsConfig.ServerName)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if c.SMTP.Security == "starttls" {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return errors.New("server does not offer STARTTLS")
+		}
+		if err := client.StartTLS(c.SMTP.tlsConfig); err != nil {
+			return err
+		}
+	}
+
+	if c.SMTP.Username != "" {
+		auth := smtp.PlainAuth("", c.SMTP.Username, c.SMTP.Password, c.SMTP.tlsConfig.ServerName)
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(c.From); err != nil {
+		return err
+	}
+	if err := client.Rcpt(c.To); err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	msg := strings.Join([]string{
+		"From: " + c.From,
+		"To: " + c.To,
+		"Subject: Status dashboard email check " + token,
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"Message-ID: <" + token + "@status-dashboard>",
+		emailCheckHeader + ": " + token,
+		"",
+		"Sent by the status dashboard to check email delivery, it is deleted on arrival.",
+		"",
+	}, "\r\n")
+	if _, err := w.Write([]byte(msg)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// find looks for the message tagged token, deleting it if it's there. An
+// error with found set is a failure to delete it.
+func (c *EmailCheck) find(ctx context.Context, token string) (bool, error) {
+	conn, err := c.Mailbox.dial(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	if c.Mailbox.Protocol == "pop3" {
+		return pop3Find(conn, c.Mailbox, token)
+	}
+	return imapFind(conn, c.Mailbox, token)
+}
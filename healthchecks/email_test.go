@@ -0,0 +1,348 @@
+package healthchecks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testMailbox is the mail the stand-in servers below share. Messages are
+// their header and body lines.
+type testMailbox struct {
+	mu         sync.Mutex
+	messages   [][]string
+	drop       bool
+	failDelete bool
+}
+
+func (m *testMailbox) deliver(lines []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.drop {
+		m.messages = append(m.messages, lines)
+	}
+}
+
+// matching is the 1-based ids of messages tagged token.
+func (m *testMailbox) matching(token string) []int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ids := []int{}
+	for i, msg := range m.messages {
+		if hasHeader(msg, emailCheckHeader, token) {
+			ids = append(ids, i+1)
+		}
+	}
+	return ids
+}
+
+func (m *testMailbox) remove(ids map[int]bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	kept := [][]string{}
+	for i, msg := range m.messages {
+		if !ids[i+1] {
+			kept = append(kept, msg)
+		}
+	}
+	m.messages = kept
+}
+
+func (m *testMailbox) len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.messages)
+}
+
+// serve accepts connections on a local port until the test ends.
+func serve(t *testing.T, handle func(tp *textproto.Conn)) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { lis.Close() })
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				handle(textproto.NewConn(conn))
+			}()
+		}
+	}()
+
+	return lis.Addr().String()
+}
+
+func serveSMTP(t *testing.T, mb *testMailbox) string {
+	return serve(t, func(tp *textproto.Conn) {
+		tp.PrintfLine("220 localhost ESMTP")
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+			switch cmd := strings.ToUpper(strings.Fields(line + " ")[0]); cmd {
+			case "EHLO", "HELO":
+				tp.PrintfLine("250 localhost")
+			case "MAIL", "RCPT", "RSET", "NOOP":
+				tp.PrintfLine("250 OK")
+			case "DATA":
+				tp.PrintfLine("354 go ahead")
+				lines, err := tp.ReadDotLines()
+				if err != nil {
+					return
+				}
+				mb.deliver(lines)
+				tp.PrintfLine("250 OK")
+			case "QUIT":
+				tp.PrintfLine("221 bye")
+				return
+			default:
+				tp.PrintfLine("502 %s not implemented", cmd)
+			}
+		}
+	})
+}
+
+func serveIMAP(t *testing.T, mb *testMailbox) string {
+	return serve(t, func(tp *textproto.Conn) {
+		tp.PrintfLine("* OK IMAP4rev1 ready")
+		deleted := map[int]bool{}
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+			fields := strings.Fields(line)
+			if len(fields) < 2 {
+				tp.PrintfLine("* BAD")
+				continue
+			}
+			tag := fields[0]
+
+			switch strings.ToUpper(fields[1]) {
+			case "LOGIN":
+				if len(fields) != 4 || fields[2] != `"checker"` || fields[3] != `"secret"` {
+					tp.PrintfLine("%s NO bad credentials", tag)
+					continue
+				}
+				tp.PrintfLine("%s OK logged in", tag)
+			case "SELECT":
+				tp.PrintfLine("* %d EXISTS", mb.len())
+				tp.PrintfLine("%s OK [READ-WRITE] selected", tag)
+			case "SEARCH":
+				ids := mb.matching(strings.Trim(fields[len(fields)-1], `"`))
+				found := ""
+				for _, id := range ids {
+					found += fmt.Sprintf(" %d", id)
+				}
+				tp.PrintfLine("* SEARCH%s", found)
+				tp.PrintfLine("%s OK search done", tag)
+			case "STORE":
+				if mb.failDelete {
+					tp.PrintfLine("%s NO mailbox is read-only", tag)
+					continue
+				}
+				for _, id := range strings.Split(fields[2], ",") {
+					var n int
+					fmt.Sscan(id, &n)
+					deleted[n] = true
+				}
+				tp.PrintfLine("%s OK stored", tag)
+			case "EXPUNGE":
+				mb.remove(deleted)
+				deleted = map[int]bool{}
+				tp.PrintfLine("%s OK expunged", tag)
+			case "LOGOUT":
+				tp.PrintfLine("* BYE")
+				tp.PrintfLine("%s OK logged out", tag)
+				return
+			default:
+				tp.PrintfLine("%s BAD unknown command", tag)
+			}
+		}
+	})
+}
+
+func servePOP3(t *testing.T, mb *testMailbox) string {
+	return serve(t, func(tp *textproto.Conn) {
+		tp.PrintfLine("+OK POP3 ready")
+		deleted := map[int]bool{}
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+			fields := strings.Fields(line)
+			if len(fields) == 0 {
+				tp.PrintfLine("-ERR empty command")
+				continue
+			}
+
+			switch strings.ToUpper(fields[0]) {
+			case "USER":
+				tp.PrintfLine("+OK")
+			case "PASS":
+				if len(fields) != 2 || fields[1] != "secret" {
+					tp.PrintfLine("-ERR bad credentials")
+					continue
+				}
+				tp.PrintfLine("+OK logged in")
+			case "LIST":
+				mb.mu.Lock()
+				tp.PrintfLine("+OK %d messages", len(mb.messages))
+				for i, msg := range mb.messages {
+					tp.PrintfLine("%d %d", i+1, len(strings.Join(msg, "\r\n")))
+				}
+				mb.mu.Unlock()
+				tp.PrintfLine(".")
+			case "TOP":
+				var n int
+				fmt.Sscan(fields[1], &n)
+				mb.mu.Lock()
+				if n < 1 || n > len(mb.messages) {
+					mb.mu.Unlock()
+					tp.PrintfLine("-ERR no such message")
+					continue
+				}
+				tp.PrintfLine("+OK")
+				for _, l := range mb.messages[n-1] {
+					if l == "" {
+						break
+					}
+					tp.PrintfLine("%s", l)
+				}
+				mb.mu.Unlock()
+				tp.PrintfLine("")
+				tp.PrintfLine(".")
+			case "DELE":
+				if mb.failDelete {
+					tp.PrintfLine("-ERR mailbox is read-only")
+					continue
+				}
+				var n int
+				fmt.Sscan(fields[1], &n)
+				deleted[n] = true
+				tp.PrintfLine("+OK deleted")
+			case "QUIT":
+				mb.remove(deleted)
+				tp.PrintfLine("+OK bye")
+				return
+			default:
+				tp.PrintfLine("-ERR unknown command")
+			}
+		}
+	})
+}
+
+func newTestEmailCheck(t *testing.T, smtpAddr, protocol, mailboxAddr string) Checker {
+	t.Helper()
+
+	config := fmt.Sprintf(`{
+		"smtp": {"address": %q, "protocol": "smtp", "security": "none"},
+		"mailbox": {"address": %q, "protocol": %q, "security": "none", "username": "checker", "password": "secret"},
+		"from": "status@cutter.test",
+		"to": "checker@cutter.test",
+		"poll_interval": "10ms"
+	}`, smtpAddr, mailboxAddr, protocol)
+
+	c, err := checkTypes["email"](json.RawMessage(config), &ChecksConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestEmailCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		protocol string
+		mailbox  *testMailbox
+		status   string
+		message  string
+		left     int
+	}{
+		{name: "imap delivered", protocol: "imap", status: StatusOperational, message: "delivered in"},
+		{name: "pop3 delivered", protocol: "pop3", status: StatusOperational, message: "delivered in"},
+		{name: "imap not deleted", protocol: "imap", mailbox: &testMailbox{failDelete: true}, status: StatusDegraded, message: "not deleted", left: 1},
+		{name: "pop3 not deleted", protocol: "pop3", mailbox: &testMailbox{failDelete: true}, status: StatusDegraded, message: "not deleted", left: 1},
+		{name: "imap never delivered", protocol: "imap", mailbox: &testMailbox{drop: true}, status: StatusDown, message: "not delivered"},
+		{name: "pop3 never delivered", protocol: "pop3", mailbox: &testMailbox{drop: true}, status: StatusDown, message: "not delivered"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mb := tt.mailbox
+			if mb == nil {
+				mb = &testMailbox{}
+			}
+			serveMailbox := serveIMAP
+			if tt.protocol == "pop3" {
+				serveMailbox = servePOP3
+			}
+			c := newTestEmailCheck(t, serveSMTP(t, mb), tt.protocol, serveMailbox(t, mb))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer cancel()
+
+			res := c.Check(ctx)
+			if res.Status != tt.status {
+				t.Fatalf("status = %s, want %s: %s", res.Status, tt.status, res.Message)
+			}
+			if !strings.Contains(res.Message, tt.message) {
+				t.Errorf("message = %q, want it to contain %q", res.Message, tt.message)
+			}
+			if n := mb.len(); n != tt.left {
+				t.Errorf("%d messages left in the mailbox, want %d", n, tt.left)
+			}
+		})
+	}
+}
+
+func TestEmailCheckBadLogin(t *testing.T) {
+	mb := &testMailbox{}
+	c := newTestEmailCheck(t, serveSMTP(t, mb), "imap", serveIMAP(t, mb)).(*EmailCheck)
+	c.Mailbox.Password = "wrong"
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	res := c.Check(ctx)
+	if res.Status != StatusDown || !strings.Contains(res.Message, "imap login") {
+		t.Errorf("got %s %q, want down with the login error", res.Status, res.Message)
+	}
+}
+
+func TestEmailCheckDefaultTimeout(t *testing.T) {
+	cfg := &ChecksConfig{Checks: []*CheckDefinition{{
+		Name: "mail",
+		Type: "email",
+		Config: json.RawMessage(`{
+			"smtp": {"address": "localhost:25", "protocol": "smtp", "security": "none"},
+			"mailbox": {"address": "localhost:143", "protocol": "imap", "security": "none"},
+			"from": "status@cutter.test",
+			"to": "checker@cutter.test"
+		}`),
+	}}}
+
+	checks, err := cfg.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checks[0].Timeout != defaultEmailTimeout {
+		t.Errorf("timeout = %s, want %s", checks[0].Timeout, defaultEmailTimeout)
+	}
+}
@@ -0,0 +1,205 @@
+package healthchecks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	registerCheckType("exec", newExecCheck)
+}
+
+// plugin output beyond this is dropped
+const maxPluginOutput = 64 << 10
+
+// ExecCheck runs a local command following the Nagios plugin conventions:
+// exit 0, 1, 2 or 3 for OK, WARNING, CRITICAL and UNKNOWN, and output of
+// the form "TEXT | perfdata". The perfdata values become the result's
+// metrics. The command is run directly, not through a shell.
+type ExecCheck struct {
+	Command string            `json:"command"`
+	Args    []string          `json:"args"`
+	Env     map[string]string `json:"env"`
+	Dir     string            `json:"dir"`
+}
+
+func newExecCheck(raw json.RawMessage, cfg *ChecksConfig) (Checker, error) {
+	c := &ExecCheck{}
+	if err := decodeConfig(raw, c); err != nil {
+		return nil, err
+	}
+	if c.Command == "" {
+		return nil, errors.New("command is required")
+	}
+	if _, err := exec.LookPath(c.Command); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+var pluginStatuses = map[int]string{
+	0: StatusOperational,
+	1: StatusDegraded,
+	2: StatusDown,
+	3: StatusUnknown,
+}
+
+func (c *ExecCheck) Check(ctx context.Context) *Result {
+	cmd := exec.CommandContext(ctx, c.Command, c.Args...)
+	cmd.Dir = c.Dir
+	cmd.Env = os.Environ()
+	for k, v := range c.Env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+
+	// stderr is kept apart so chatter can't become the status text or
+	// break the perfdata
+	out := &limitedBuffer{max: maxPluginOutput}
+	stderr := &limitedBuffer{max: maxPluginOutput}
+	cmd.Stdout = out
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	if ctx.Err() != nil {
+		return &Result{Status: StatusDown, Message: "plugin timed out"}
+	}
+
+	code := 0
+	if err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return &Result{Status: StatusUnknown, Message: err.Error()}
+		}
+		code = exitErr.ExitCode()
+	}
+
+	status, ok := pluginStatuses[code]
+	if !ok {
+		status = StatusUnknown
+	}
+
+	text, perfdata := parsePluginOutput(out.String())
+	if text == "" {
+		text = fmt.Sprintf("exit status %d", code)
+	}
+	if e := strings.TrimSpace(stderr.String()); code != 0 && e != "" {
+		text += ": " + e
+	}
+
+	return &Result{Status: status, Message: text, Metrics: perfdata}
+}
+
+// parsePluginOutput splits plugin output into the first line of text and
+// the perfdata values. Perfdata follows a | on the first line and, after
+// a | in the long text, on every line from there on.
+func parsePluginOutput(out string) (string, map[string]float64) {
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+
+	perf := []string{}
+	text := lines[0]
+	if i := strings.IndexByte(text, '|'); i != -1 {
+		perf = append(perf, text[i+1:])
+		text = text[:i]
+	}
+
+	inPerf := false
+	for _, line := range lines[1:] {
+		if inPerf {
+			perf = append(perf, line)
+			continue
+		}
+		if i := strings.IndexByte(line, '|'); i != -1 {
+			perf = append(perf, line[i+1:])
+			inPerf = true
+		}
+	}
+
+	metrics := map[string]float64{}
+	for _, p := range perf {
+		for label, v := range parsePerfdata(p) {
+			metrics[label] = v
+		}
+	}
+	if len(metrics) == 0 {
+		metrics = nil
+	}
+
+	return strings.TrimSpace(text), metrics
+}
+
+// parsePerfdata reads 'label'=value[UOM];[warn];[crit];[min];[max] items,
+// keeping each label's value. Items that don't parse are skipped.
+func parsePerfdata(s string) map[string]float64 {
+	ret := map[string]float64{}
+
+	for _, item := range splitPerfdata(s) {
+		eq := strings.LastIndexByte(item, '=')
+		if eq <= 0 {
+			continue
+		}
+
+		label := strings.Trim(item[:eq], "'")
+		value := strings.SplitN(item[eq+1:], ";", 2)[0]
+		value = strings.TrimRight(value, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ%")
+
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			continue
+		}
+		ret[label] = v
+	}
+
+	return ret
+}
+
+// splitPerfdata splits on spaces outside single quoted labels.
+func splitPerfdata(s string) []string {
+	items := []string{}
+	var cur strings.Builder
+	quoted := false
+
+	for _, r := range s {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+			cur.WriteRune(r)
+		case r == ' ' && !quoted:
+			if cur.Len() > 0 {
+				items = append(items, cur.String())
+				cur.Reset()
+			}
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if cur.Len() > 0 {
+		items = append(items, cur.String())
+	}
+
+	return items
+}
+
+// limitedBuffer keeps the first max bytes written to it.
+type limitedBuffer struct {
+	bytes.Buffer
+	max int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.max - b.Len(); room > 0 {
+		if len(p) > room {
+			b.Buffer.Write(p[:room])
+		} else {
+			b.Buffer.Write(p)
+		}
+	}
+	return len(p), nil
+}
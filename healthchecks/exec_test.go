@@ -0,0 +1,60 @@
+package healthchecks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestExecCheckStderr(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		status  string
+		message string
+		metrics int
+	}{
+		{
+			name:    "ignored on success",
+			script:  "echo 'deprecated flag' >&2; echo 'OK - 3 jobs | jobs=3'",
+			status:  StatusOperational,
+			message: "OK - 3 jobs",
+			metrics: 1,
+		},
+		{
+			name:    "appended on failure",
+			script:  "echo 'CRITICAL - queue stuck | jobs=0'; echo 'connection refused' >&2; exit 2",
+			status:  StatusDown,
+			message: "CRITICAL - queue stuck: connection refused",
+			metrics: 1,
+		},
+		{
+			name:    "alone on failure",
+			script:  "echo 'connection refused' >&2; exit 3",
+			status:  StatusUnknown,
+			message: "exit status 3: connection refused",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := fmt.Sprintf(`{"command": "sh", "args": ["-c", %q]}`, tt.script)
+			c, err := checkTypes["exec"](json.RawMessage(config), &ChecksConfig{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res := c.Check(context.Background())
+			if res.Status != tt.status {
+				t.Errorf("status = %s, want %s", res.Status, tt.status)
+			}
+			if res.Message != tt.message {
+				t.Errorf("message = %q, want %q", res.Message, tt.message)
+			}
+			if len(res.Metrics) != tt.metrics {
+				t.Errorf("metrics = %v, want %d", res.Metrics, tt.metrics)
+			}
+		})
+	}
+}
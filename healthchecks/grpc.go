@@ -0,0 +1,106 @@
+package healthchecks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func init() {
+	registerCheckType("grpc", newGRPCCheckFromConfig)
+}
+
+// GRPCCheck calls grpc.health.v1.Health/Check for Service, the empty name
+// asking after the server as a whole.
+type GRPCCheck struct {
+	Address string `json:"address"`
+	Service string `json:"service"`
+
+	// Deadline bounds each call, the check's timeout applies regardless.
+	Deadline Duration `json:"deadline"`
+
+	// TLS is used unless Plaintext is set.
+	TLS       *TLSConfig `json:"tls"`
+	Plaintext bool       `json:"plaintext"`
+
+	client healthpb.HealthClient
+}
+
+func newGRPCCheckFromConfig(raw json.RawMessage, cfg *ChecksConfig) (Checker, error) {
+	c := &GRPCCheck{}
+	if err := decodeConfig(raw, c); err != nil {
+		return nil, err
+	}
+	if c.Address == "" {
+		return nil, errors.New("address is required")
+	}
+
+	opts := []grpc.DialOption{}
+	if c.Plaintext {
+		opts = append(opts, grpc.WithInsecure())
+	} else {
+		tlsConfig, err := c.TLS.Build()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	}
+
+	// connecting is lazy, an unreachable server fails the checks rather
+	// than startup
+	conn, err := grpc.Dial(c.Address, opts...)
+	if err != nil {
+		return nil, err
+	}
+	c.client = healthpb.NewHealthClient(conn)
+
+	return c, nil
+}
+
+var servingStatuses = map[healthpb.HealthCheckResponse_ServingStatus]string{
+	healthpb.HealthCheckResponse_SERVING:         StatusOperational,
+	healthpb.HealthCheckResponse_NOT_SERVING:     StatusDown,
+	healthpb.HealthCheckResponse_UNKNOWN:         StatusUnknown,
+	healthpb.HealthCheckResponse_SERVICE_UNKNOWN: StatusUnknown,
+}
+
+func (c *GRPCCheck) Check(ctx context.Context) *Result {
+	if c.Deadline.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Deadline.Duration)
+		defer cancel()
+	}
+
+	start := time.Now()
+	resp, err := c.client.Check(ctx, &healthpb.HealthCheckRequest{Service: c.Service})
+	metrics := map[string]float64{"rpc_ms": float64(time.Since(start).Milliseconds())}
+
+	if err != nil {
+		res := &Result{Status: StatusDown, Message: err.Error(), Metrics: metrics}
+		switch status.Code(err) {
+		case codes.Unimplemented:
+			res.Status = StatusUnknown
+			res.Message = "server does not implement grpc.health.v1.Health"
+		case codes.NotFound:
+			// the service isn't registered, so isn't serving
+			res.Message = "server does not know service " + c.Service
+		case codes.DeadlineExceeded:
+			res.Message = "timed out waiting for " + c.Address
+		}
+		return res
+	}
+
+	s, ok := servingStatuses[resp.Status]
+	if !ok {
+		s = StatusUnknown
+	}
+
+	return &Result{Status: s, Message: resp.Status.String(), Metrics: metrics}
+}
@@ -0,0 +1,102 @@
+package healthchecks
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// dialInProcess serves srv on an in-memory listener and connects to it.
+func dialInProcess(t *testing.T, srv healthpb.HealthServer) *grpc.ClientConn {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	healthpb.RegisterHealthServer(s, srv)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithInsecure(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+// grpcCheck checks service over conn.
+func grpcCheck(conn *grpc.ClientConn, service string) *GRPCCheck {
+	return &GRPCCheck{Address: conn.Target(), Service: service, client: healthpb.NewHealthClient(conn)}
+}
+
+func TestGRPCCheck(t *testing.T) {
+	srv := health.NewServer()
+	srv.SetServingStatus("orders", healthpb.HealthCheckResponse_SERVING)
+	srv.SetServingStatus("payments", healthpb.HealthCheckResponse_NOT_SERVING)
+	conn := dialInProcess(t, srv)
+
+	tests := []struct {
+		service string
+		status  string
+	}{
+		{"", StatusOperational},
+		{"orders", StatusOperational},
+		{"payments", StatusDown},
+		{"shipping", StatusDown},
+	}
+
+	for _, tt := range tests {
+		res := grpcCheck(conn, tt.service).Check(context.Background())
+		if res.Status != tt.status {
+			t.Errorf("%q: status = %s, want %s: %s", tt.service, res.Status, tt.status, res.Message)
+		}
+		if _, ok := res.Metrics["rpc_ms"]; !ok {
+			t.Errorf("%q: no rpc_ms metric", tt.service)
+		}
+	}
+}
+
+// slowHealth answers no call before its context is done.
+type slowHealth struct {
+	healthpb.UnimplementedHealthServer
+}
+
+func (slowHealth) Check(ctx context.Context, _ *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestGRPCCheckDeadline(t *testing.T) {
+	c := grpcCheck(dialInProcess(t, slowHealth{}), "")
+	c.Deadline = Duration{50 * time.Millisecond}
+
+	start := time.Now()
+	res := c.Check(context.Background())
+	if res.Status != StatusDown {
+		t.Fatalf("status = %s, want %s: %s", res.Status, StatusDown, res.Message)
+	}
+	if !strings.Contains(res.Message, "timed out") {
+		t.Errorf("message = %q, want a timeout", res.Message)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("took %s, the deadline was not applied", elapsed)
+	}
+}
+
+func TestGRPCCheckUnimplemented(t *testing.T) {
+	res := grpcCheck(dialInProcess(t, healthpb.UnimplementedHealthServer{}), "").Check(context.Background())
+	if res.Status != StatusUnknown {
+		t.Errorf("status = %s, want %s: %s", res.Status, StatusUnknown, res.Message)
+	}
+}
@@ -0,0 +1,91 @@
+package healthchecks
+
+import (
+	"sort"
+	"strings"
+)
+
+// Component is a part of a service, its database or a downstream API,
+// reported in the service's own health response.
+type Component struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Output string `json:"output,omitempty"`
+}
+
+// HealthObservation is one entry of an application/health+json "checks"
+// map, see https://tools.ietf.org/html/draft-inadarei-api-health-check.
+type HealthObservation struct {
+	ComponentId   string      `json:"componentId,omitempty"`
+	ComponentType string      `json:"componentType,omitempty"`
+	ObservedValue interface{} `json:"observedValue,omitempty"`
+	ObservedUnit  string      `json:"observedUnit,omitempty"`
+	Status        string      `json:"status,omitempty"`
+	Time          string      `json:"time,omitempty"`
+	Output        string      `json:"output,omitempty"`
+}
+
+// actuatorComponent is a Spring Boot actuator style component.
+type actuatorComponent struct {
+	Status  string                 `json:"status"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// HealthStatus maps the status words used by health responses onto the
+// dashboard's statuses.
+func HealthStatus(s string) string {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "pass", "ok", "up", "healthy", "operational", "200", "201":
+		return StatusOperational
+	case "warn", "warning", "degraded":
+		return StatusDegraded
+	case "fail", "down", "error", "unhealthy", "out_of_service":
+		return StatusDown
+	}
+
+	return StatusUnknown
+}
+
+// State is the dashboard status for a service's own health response, or
+// the raw status when it isn't one HealthStatus knows.
+func (s *ServiceResponse) State() string {
+	if status := HealthStatus(s.Status); status != StatusUnknown {
+		return status
+	}
+	return s.Status
+}
+
+// Components flattens a health response's checks into one Component per
+// check, the worst observation winning when a check has several.
+func (s *ServiceResponse) Components() []*Component {
+	byName := map[string]*Component{}
+
+	for name, observations := range s.Checks {
+		for _, o := range observations {
+			status := HealthStatus(o.Status)
+			c, ok := byName[name]
+			if !ok {
+				byName[name] = &Component{Name: name, Status: status, Output: o.Output}
+				continue
+			}
+			if worse(c.Status, status) != c.Status {
+				c.Status = status
+				c.Output = o.Output
+			}
+		}
+	}
+
+	for name, a := range s.ActuatorComponents {
+		if _, ok := byName[name]; !ok {
+			byName[name] = &Component{Name: name, Status: HealthStatus(a.Status)}
+		}
+	}
+
+	ret := make([]*Component, 0, len(byName))
+	for _, c := range byName {
+		ret = append(ret, c)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Name < ret[j].Name })
+
+	return ret
+}
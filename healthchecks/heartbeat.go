@@ -0,0 +1,173 @@
+package healthchecks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+func init() {
+	registerCheckType("heartbeat", newHeartbeatCheck)
+}
+
+// tokens are part of a public URL so must not be guessable
+const minHeartbeatToken = 16
+
+// HeartbeatCheck is a push monitor for jobs with nothing to poll. The job
+// pings its URL, see server.Heartbeat, and the check goes down when a run
+// is late by more than Grace or reports failure. Runs are expected every
+// Period, or at the cron Schedule in Timezone.
+type HeartbeatCheck struct {
+	Token    string   `json:"token"`
+	Period   Duration `json:"period"`
+	Schedule string   `json:"schedule"`
+	Timezone string   `json:"timezone"`
+	Grace    Duration `json:"grace"`
+
+	schedule *cronSchedule
+	loc      *time.Location
+
+	mu        sync.Mutex
+	since     time.Time
+	started   time.Time
+	succeeded time.Time
+	failed    time.Time
+	duration  time.Duration
+	output    string
+}
+
+func newHeartbeatCheck(raw json.RawMessage, cfg *ChecksConfig) (Checker, error) {
+	c := &HeartbeatCheck{Timezone: "UTC"}
+	if err := decodeConfig(raw, c); err != nil {
+		return nil, err
+	}
+	if len(c.Token) < minHeartbeatToken {
+		return nil, fmt.Errorf("token must be at least %d characters", minHeartbeatToken)
+	}
+	if (c.Period.Duration > 0) == (c.Schedule != "") {
+		return nil, errors.New("one of period or schedule is required")
+	}
+
+	if c.Schedule != "" {
+		schedule, err := parseCron(c.Schedule)
+		if err != nil {
+			return nil, err
+		}
+		c.schedule = schedule
+	}
+
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	c.loc = loc
+
+	// until the first ping, monitoring starting stands in for the last run
+	c.since = time.Now()
+
+	return c, nil
+}
+
+// Start records that a run began, so its duration can be measured.
+func (c *HeartbeatCheck) Start() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.started = time.Now()
+}
+
+// Success records a completed run.
+func (c *HeartbeatCheck) Success(output string) {
+	c.finish(&c.succeeded, output)
+}
+
+// Fail records a run that reported failure.
+func (c *HeartbeatCheck) Fail(output string) {
+	c.finish(&c.failed, output)
+}
+
+// LastPing is when the job last pinged, zero if it never has.
+func (c *HeartbeatCheck) LastPing() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	last := c.started
+	for _, t := range []time.Time{c.succeeded, c.failed} {
+		if t.After(last) {
+			last = t
+		}
+	}
+	return last
+}
+
+func (c *HeartbeatCheck) finish(at *time.Time, output string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if !c.started.IsZero() && c.started.After(c.succeeded) && c.started.After(c.failed) {
+		c.duration = now.Sub(c.started)
+	}
+
+	*at = now
+	c.output = output
+}
+
+func (c *HeartbeatCheck) Check(ctx context.Context) *Result {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	res := &Result{Status: StatusOperational, Metrics: map[string]float64{}}
+
+	last := c.succeeded
+	if last.IsZero() {
+		last = c.since
+	} else {
+		res.Metrics["seconds_since_success"] = now.Sub(c.succeeded).Seconds()
+	}
+	if c.duration > 0 {
+		res.Metrics["duration_seconds"] = c.duration.Seconds()
+	}
+
+	if c.failed.After(c.succeeded) {
+		res.Status = StatusDown
+		res.Message = "run failed at " + c.failed.UTC().Format(time.RFC3339)
+		if c.output != "" {
+			res.Message += ": " + truncate(c.output, 200)
+		}
+		return res
+	}
+
+	due := c.due(last)
+	if due.IsZero() {
+		res.Status = StatusUnknown
+		res.Message = "schedule never fires"
+		return res
+	}
+
+	if now.After(due.Add(c.Grace.Duration)) {
+		res.Status = StatusDown
+		res.Message = fmt.Sprintf("no ping since %s, a run was due %s", last.UTC().Format(time.RFC3339), due.UTC().Format(time.RFC3339))
+		return res
+	}
+
+	if c.started.After(c.succeeded) && c.started.After(c.failed) {
+		res.Message = "running since " + c.started.UTC().Format(time.RFC3339)
+	} else if !c.succeeded.IsZero() {
+		res.Message = "last run " + c.succeeded.UTC().Format(time.RFC3339)
+	}
+
+	return res
+}
+
+// due is when the run after one finishing at last should have pinged by.
+func (c *HeartbeatCheck) due(last time.Time) time.Time {
+	if c.schedule != nil {
+		return c.schedule.Next(last.In(c.loc))
+	}
+	return last.Add(c.Period.Duration)
+}
@@ -0,0 +1,129 @@
+package healthchecks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// responses are read up to this size, health bodies are small
+const maxBodySize = 1 << 20
+
+func init() {
+	registerCheckType("http", newHTTPCheck)
+}
+
+// HTTPCheck requests URL and is operational when the response code is one
+// of ExpectedStatus, any 2xx by default.
+type HTTPCheck struct {
+	URL            string            `json:"url"`
+	Method         string            `json:"method"`
+	Headers        map[string]string `json:"headers"`
+	ExpectedStatus []int             `json:"expected_status"`
+	TLS            *TLSConfig        `json:"tls"`
+
+	client *http.Client
+}
+
+func newHTTPCheck(raw json.RawMessage, cfg *ChecksConfig) (Checker, error) {
+	c := &HTTPCheck{Method: "GET"}
+	if err := decodeConfig(raw, c); err != nil {
+		return nil, err
+	}
+	if err := c.init(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *HTTPCheck) init() error {
+	if c.URL == "" {
+		return errors.New("url is required")
+	}
+
+	transport, err := NewTransport(c.TLS)
+	if err != nil {
+		return err
+	}
+	c.client = &http.Client{Transport: transport}
+
+	return nil
+}
+
+func (c *HTTPCheck) Check(ctx context.Context) *Result {
+	resp, body, err := c.fetch(ctx)
+	if err != nil {
+		return &Result{Status: StatusDown, Message: err.Error()}
+	}
+
+	res := &Result{Status: StatusOperational, Message: strings.TrimSpace(resp.Status)}
+	if !c.expected(resp.StatusCode) {
+		res.Status = StatusDown
+		if len(body) > 0 {
+			res.Message = fmt.Sprintf("%s: %s", res.Message, truncate(string(body), 200))
+		}
+	}
+
+	// a health response body can say more than the status code
+	health := &ServiceResponse{header: resp.Header}
+	if json.Unmarshal(body, health) == nil {
+		if status := HealthStatus(health.Status); health.Status != "" && status != StatusUnknown {
+			res.Status = worse(res.Status, status)
+		}
+		res.Components = health.Components()
+	}
+	res.Version = health.BuildInfo()
+
+	return res
+}
+
+func (c *HTTPCheck) fetch(ctx context.Context) (*http.Response, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, c.Method, c.URL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	for k, v := range c.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp, body, nil
+}
+
+func (c *HTTPCheck) expected(code int) bool {
+	if len(c.ExpectedStatus) == 0 {
+		return code >= 200 && code < 300
+	}
+
+	for _, s := range c.ExpectedStatus {
+		if s == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+func truncate(s string, n int) string {
+	s = strings.TrimSpace(s)
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
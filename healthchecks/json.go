@@ -0,0 +1,100 @@
+package healthchecks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+func init() {
+	registerCheckType("json", newJSONCheck)
+}
+
+// JSONCheck fetches a JSON document and compares the numbers at each of
+// Metrics' paths with their thresholds, e.g. queue depth in an admin
+// endpoint. The values are kept as the result's metrics.
+type JSONCheck struct {
+	HTTPCheck
+
+	Metrics map[string]*JSONMetric `json:"metrics"`
+}
+
+type JSONMetric struct {
+	Path string `json:"path"`
+	Threshold
+
+	path *jsonPath
+}
+
+func newJSONCheck(raw json.RawMessage, cfg *ChecksConfig) (Checker, error) {
+	c := &JSONCheck{HTTPCheck: HTTPCheck{Method: "GET"}}
+	if err := decodeConfig(raw, c); err != nil {
+		return nil, err
+	}
+	if err := c.HTTPCheck.init(); err != nil {
+		return nil, err
+	}
+	if len(c.Metrics) == 0 {
+		return nil, errors.New("metrics are required")
+	}
+
+	for name, m := range c.Metrics {
+		p, err := parseJSONPath(m.Path)
+		if err != nil {
+			return nil, fmt.Errorf("metric %s: %v", name, err)
+		}
+		m.path = p
+	}
+
+	return c, nil
+}
+
+func (c *JSONCheck) Check(ctx context.Context) *Result {
+	resp, body, err := c.fetch(ctx)
+	if err != nil {
+		return &Result{Status: StatusDown, Message: err.Error()}
+	}
+	if !c.expected(resp.StatusCode) {
+		return &Result{Status: StatusDown, Message: strings.TrimSpace(resp.Status)}
+	}
+
+	var doc interface{}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return &Result{Status: StatusUnknown, Message: "invalid json: " + err.Error()}
+	}
+
+	res := &Result{Status: StatusOperational, Metrics: map[string]float64{}}
+	problems := []string{}
+
+	names := make([]string, 0, len(c.Metrics))
+	for name := range c.Metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		m := c.Metrics[name]
+
+		v, err := m.path.number(doc)
+		if err != nil {
+			res.Status = worse(res.Status, StatusUnknown)
+			problems = append(problems, err.Error())
+			continue
+		}
+		res.Metrics[name] = v
+
+		if status := m.Evaluate(v); status != StatusOperational {
+			res.Status = worse(res.Status, status)
+			problems = append(problems, m.describe(name, v))
+		}
+	}
+	res.Message = strings.Join(problems, "; ")
+
+	return res
+}
@@ -0,0 +1,112 @@
+package healthchecks
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// jsonPath is a parsed JSONPath expression. Only the subset needed to pick
+// one value out of a document is supported: $, .key, ['key'] and [index].
+type jsonPath struct {
+	expr  string
+	steps []pathStep
+}
+
+type pathStep struct {
+	key   string
+	index int
+	isKey bool
+}
+
+func parseJSONPath(expr string) (*jsonPath, error) {
+	p := &jsonPath{expr: expr}
+
+	s := strings.TrimSpace(expr)
+	s = strings.TrimPrefix(s, "$")
+
+	for len(s) > 0 {
+		switch {
+		case s[0] == '.':
+			s = s[1:]
+			end := strings.IndexAny(s, ".[")
+			if end == -1 {
+				end = len(s)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("jsonpath %q: empty key", expr)
+			}
+			p.steps = append(p.steps, pathStep{key: s[:end], isKey: true})
+			s = s[end:]
+		case s[0] == '[':
+			end := strings.IndexByte(s, ']')
+			if end == -1 {
+				return nil, fmt.Errorf("jsonpath %q: unterminated [", expr)
+			}
+			inner := strings.TrimSpace(s[1:end])
+			s = s[end+1:]
+
+			if len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0] {
+				p.steps = append(p.steps, pathStep{key: inner[1 : len(inner)-1], isKey: true})
+				continue
+			}
+			i, err := strconv.Atoi(inner)
+			if err != nil || i < 0 {
+				return nil, fmt.Errorf("jsonpath %q: unsupported index [%s]", expr, inner)
+			}
+			p.steps = append(p.steps, pathStep{index: i})
+		default:
+			if len(p.steps) > 0 {
+				return nil, fmt.Errorf("jsonpath %q: unexpected %q", expr, s)
+			}
+			// a bare leading key, "a.b" for "$.a.b"
+			s = "." + s
+		}
+	}
+
+	return p, nil
+}
+
+// number finds the value at p in doc, decoded with UseNumber, as a float.
+// Numeric strings and booleans (as 1 or 0) are accepted too.
+func (p *jsonPath) number(doc interface{}) (float64, error) {
+	v := doc
+	for _, step := range p.steps {
+		switch node := v.(type) {
+		case map[string]interface{}:
+			if !step.isKey {
+				return 0, fmt.Errorf("%s: [%d] on an object", p.expr, step.index)
+			}
+			child, ok := node[step.key]
+			if !ok {
+				return 0, fmt.Errorf("%s: no key %q", p.expr, step.key)
+			}
+			v = child
+		case []interface{}:
+			if step.isKey {
+				return 0, fmt.Errorf("%s: key %q on an array", p.expr, step.key)
+			}
+			if step.index >= len(node) {
+				return 0, fmt.Errorf("%s: index %d out of range", p.expr, step.index)
+			}
+			v = node[step.index]
+		default:
+			return 0, fmt.Errorf("%s: path continues past a scalar", p.expr)
+		}
+	}
+
+	switch n := v.(type) {
+	case json.Number:
+		return n.Float64()
+	case string:
+		return strconv.ParseFloat(strings.TrimSpace(n), 64)
+	case bool:
+		if n {
+			return 1, nil
+		}
+		return 0, nil
+	}
+
+	return 0, fmt.Errorf("%s: not a number", p.expr)
+}
@@ -0,0 +1,267 @@
+package healthchecks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// KubeClient lists the Kubernetes objects checks look at. Selector is a
+// label selector like "app=platform-api,tier!=batch", empty for all.
+type KubeClient interface {
+	Deployments(ctx context.Context, namespace, selector string) ([]*Deployment, error)
+	StatefulSets(ctx context.Context, namespace, selector string) ([]*StatefulSet, error)
+	Pods(ctx context.Context, namespace, selector string) ([]*Pod, error)
+	Services(ctx context.Context, namespace, selector string) ([]*Service, error)
+	Ingresses(ctx context.Context, namespace, selector string) ([]*Ingress, error)
+}
+
+// ObjectMeta and the types below hold the fields checks use of the
+// Kubernetes objects of the same name.
+type ObjectMeta struct {
+	Name              string            `json:"name"`
+	Namespace         string            `json:"namespace"`
+	Labels            map[string]string `json:"labels"`
+	Annotations       map[string]string `json:"annotations"`
+	CreationTimestamp time.Time         `json:"creationTimestamp"`
+}
+
+type Deployment struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Spec     struct {
+		Replicas *int `json:"replicas"`
+	} `json:"spec"`
+	Status struct {
+		Replicas            int `json:"replicas"`
+		ReadyReplicas       int `json:"readyReplicas"`
+		AvailableReplicas   int `json:"availableReplicas"`
+		UnavailableReplicas int `json:"unavailableReplicas"`
+	} `json:"status"`
+}
+
+type StatefulSet struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Spec     struct {
+		Replicas *int `json:"replicas"`
+	} `json:"spec"`
+	Status struct {
+		Replicas      int `json:"replicas"`
+		ReadyReplicas int `json:"readyReplicas"`
+	} `json:"status"`
+}
+
+type Pod struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Status   struct {
+		Phase             string             `json:"phase"`
+		Reason            string             `json:"reason"`
+		ContainerStatuses []*ContainerStatus `json:"containerStatuses"`
+	} `json:"status"`
+}
+
+type ContainerStatus struct {
+	Name         string `json:"name"`
+	Ready        bool   `json:"ready"`
+	RestartCount int    `json:"restartCount"`
+	State        struct {
+		Waiting *struct {
+			Reason  string `json:"reason"`
+			Message string `json:"message"`
+		} `json:"waiting"`
+	} `json:"state"`
+	LastState struct {
+		Terminated *struct {
+			Reason     string    `json:"reason"`
+			ExitCode   int       `json:"exitCode"`
+			FinishedAt time.Time `json:"finishedAt"`
+		} `json:"terminated"`
+	} `json:"lastState"`
+}
+
+type Service struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Spec     struct {
+		Ports []struct {
+			Name string `json:"name"`
+			Port int    `json:"port"`
+		} `json:"ports"`
+	} `json:"spec"`
+}
+
+type Ingress struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Spec     struct {
+		TLS []struct {
+			Hosts []string `json:"hosts"`
+		} `json:"tls"`
+		Rules []struct {
+			Host string `json:"host"`
+		} `json:"rules"`
+	} `json:"spec"`
+}
+
+// replicas defaults to 1 like the API server does.
+func replicas(n *int) int {
+	if n == nil {
+		return 1
+	}
+	return *n
+}
+
+// KubeConfig is how to reach the API server, in cluster through the pod's
+// service account when left empty. The account needs get and list on
+// what the checks read.
+type KubeConfig struct {
+	Server    string     `json:"server"`
+	TokenFile string     `json:"token_file"`
+	TLS       *TLSConfig `json:"tls"`
+
+	client KubeClient
+}
+
+// Kube is the client for the checks file's kubernetes section, shared
+// between the checks using it.
+func (cfg *ChecksConfig) Kube() (KubeClient, error) {
+	if cfg.Kubernetes == nil {
+		cfg.Kubernetes = &KubeConfig{}
+	}
+	if cfg.Kubernetes.client != nil {
+		return cfg.Kubernetes.client, nil
+	}
+
+	client, err := NewKubeClient(cfg.Kubernetes)
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes: %v", err)
+	}
+	cfg.Kubernetes.client = client
+
+	return client, nil
+}
+
+type kubeClient struct {
+	server    string
+	tokenFile string
+	http      *http.Client
+}
+
+// NewKubeClient is a KubeClient talking to the API server's REST API.
+func NewKubeClient(cfg *KubeConfig) (KubeClient, error) {
+	c := &kubeClient{server: cfg.Server, tokenFile: cfg.TokenFile}
+
+	tlsConfig := cfg.TLS
+	if c.server == "" {
+		host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+		if host == "" || port == "" {
+			return nil, fmt.Errorf("not running in a cluster and no server configured")
+		}
+		c.server = "https://" + net.JoinHostPort(host, port)
+
+		if c.tokenFile == "" {
+			c.tokenFile = serviceAccountDir + "/token"
+		}
+		if tlsConfig == nil {
+			tlsConfig = &TLSConfig{CAFile: serviceAccountDir + "/ca.crt"}
+		}
+	}
+	c.server = strings.TrimSuffix(c.server, "/")
+
+	transport, err := NewTransport(tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	c.http = &http.Client{Transport: transport}
+
+	return c, nil
+}
+
+func (c *kubeClient) Deployments(ctx context.Context, namespace, selector string) ([]*Deployment, error) {
+	list := &struct {
+		Items []*Deployment `json:"items"`
+	}{}
+	err := c.list(ctx, "/apis/apps/v1", namespace, "deployments", selector, list)
+	return list.Items, err
+}
+
+func (c *kubeClient) StatefulSets(ctx context.Context, namespace, selector string) ([]*StatefulSet, error) {
+	list := &struct {
+		Items []*StatefulSet `json:"items"`
+	}{}
+	err := c.list(ctx, "/apis/apps/v1", namespace, "statefulsets", selector, list)
+	return list.Items, err
+}
+
+func (c *kubeClient) Pods(ctx context.Context, namespace, selector string) ([]*Pod, error) {
+	list := &struct {
+		Items []*Pod `json:"items"`
+	}{}
+	err := c.list(ctx, "/api/v1", namespace, "pods", selector, list)
+	return list.Items, err
+}
+
+func (c *kubeClient) Services(ctx context.Context, namespace, selector string) ([]*Service, error) {
+	list := &struct {
+		Items []*Service `json:"items"`
+	}{}
+	err := c.list(ctx, "/api/v1", namespace, "services", selector, list)
+	return list.Items, err
+}
+
+func (c *kubeClient) Ingresses(ctx context.Context, namespace, selector string) ([]*Ingress, error) {
+	list := &struct {
+		Items []*Ingress `json:"items"`
+	}{}
+	err := c.list(ctx, "/apis/networking.k8s.io/v1", namespace, "ingresses", selector, list)
+	return list.Items, err
+}
+
+// list GETs a collection into v, across all namespaces when namespace is
+// empty.
+func (c *kubeClient) list(ctx context.Context, group, namespace, resource, selector string, v interface{}) error {
+	path := group + "/" + resource
+	if namespace != "" {
+		path = group + "/namespaces/" + url.PathEscape(namespace) + "/" + resource
+	}
+
+	u := c.server + path
+	if selector != "" {
+		u += "?" + url.Values{"labelSelector": {selector}}.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	// service account tokens are rotated, so read it every time
+	if c.tokenFile != "" {
+		token, err := ioutil.ReadFile(c.tokenFile)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("list %s: %s: %s", resource, resp.Status, truncate(string(body), 200))
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
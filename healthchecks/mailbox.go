@@ -0,0 +1,201 @@
+package healthchecks
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+)
+
+// POP3 has no search so only this many of the newest messages are looked at
+const maxPOP3Scan = 50
+
+// imapFind logs in, searches INBOX for the message tagged token and
+// deletes it. Only what the check needs of IMAP4rev1 is implemented.
+func imapFind(conn net.Conn, m *MailServer, token string) (bool, error) {
+	tp := textproto.NewConn(conn)
+
+	greeting, err := tp.ReadLine()
+	if err != nil {
+		return false, err
+	}
+	if !strings.HasPrefix(greeting, "* OK") && !strings.HasPrefix(greeting, "* PREAUTH") {
+		return false, fmt.Errorf("imap: unexpected greeting %q", truncate(greeting, 200))
+	}
+
+	tag := 0
+	cmd := func(format string, args ...interface{}) ([]string, error) {
+		tag++
+		t := fmt.Sprintf("a%d", tag)
+		if err := tp.PrintfLine(t+" "+format, args...); err != nil {
+			return nil, err
+		}
+		return imapResponse(tp, t)
+	}
+
+	if !strings.HasPrefix(greeting, "* PREAUTH") {
+		if _, err := cmd("LOGIN %s %s", imapQuote(m.Username), imapQuote(m.Password)); err != nil {
+			return false, fmt.Errorf("imap login: %v", err)
+		}
+	}
+	defer cmd("LOGOUT")
+
+	if _, err := cmd("SELECT INBOX"); err != nil {
+		return false, fmt.Errorf("imap select: %v", err)
+	}
+
+	lines, err := cmd("SEARCH HEADER %s %s", emailCheckHeader, imapQuote(token))
+	if err != nil {
+		return false, fmt.Errorf("imap search: %v", err)
+	}
+
+	ids := []string{}
+	for _, line := range lines {
+		if strings.HasPrefix(line, "* SEARCH") {
+			ids = append(ids, strings.Fields(line)[2:]...)
+		}
+	}
+	if len(ids) == 0 {
+		return false, nil
+	}
+
+	if _, err := cmd("STORE %s +FLAGS.SILENT (\\Deleted)", strings.Join(ids, ",")); err != nil {
+		return true, fmt.Errorf("imap store: %v", err)
+	}
+	if _, err := cmd("EXPUNGE"); err != nil {
+		return true, fmt.Errorf("imap expunge: %v", err)
+	}
+
+	return true, nil
+}
+
+// imapResponse reads untagged lines up to the one tagged tag, an error
+// unless that says OK. Literals are skipped.
+func imapResponse(tp *textproto.Conn, tag string) ([]string, error) {
+	lines := []string{}
+
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return nil, err
+		}
+
+		if strings.HasPrefix(line, tag+" ") {
+			status := line[len(tag)+1:]
+			if strings.HasPrefix(status, "OK") {
+				return lines, nil
+			}
+			return nil, errors.New(status)
+		}
+
+		if i := strings.LastIndexByte(line, '{'); i != -1 && strings.HasSuffix(line, "}") {
+			if n, err := strconv.ParseInt(line[i+1:len(line)-1], 10, 64); err == nil {
+				if _, err := io.CopyN(ioutil.Discard, tp.R, n); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		lines = append(lines, line)
+	}
+}
+
+func imapQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// pop3Find logs in, looks through the newest messages' headers for the one
+// tagged token and deletes it.
+func pop3Find(conn net.Conn, m *MailServer, token string) (bool, error) {
+	tp := textproto.NewConn(conn)
+
+	cmd := func(format string, args ...interface{}) (string, error) {
+		if format != "" {
+			if err := tp.PrintfLine(format, args...); err != nil {
+				return "", err
+			}
+		}
+		line, err := tp.ReadLine()
+		if err != nil {
+			return "", err
+		}
+		if !strings.HasPrefix(line, "+OK") {
+			return "", errors.New(line)
+		}
+		return line, nil
+	}
+
+	if _, err := cmd(""); err != nil {
+		return false, fmt.Errorf("pop3: unexpected greeting: %v", err)
+	}
+	if _, err := cmd("USER %s", m.Username); err != nil {
+		return false, fmt.Errorf("pop3 login: %v", err)
+	}
+	if _, err := cmd("PASS %s", m.Password); err != nil {
+		return false, fmt.Errorf("pop3 login: %v", err)
+	}
+
+	// deletions only happen on a clean QUIT
+	quit := func() error {
+		_, err := cmd("QUIT")
+		return err
+	}
+
+	if _, err := cmd("LIST"); err != nil {
+		quit()
+		return false, fmt.Errorf("pop3 list: %v", err)
+	}
+	listing, err := tp.ReadDotLines()
+	if err != nil {
+		return false, err
+	}
+
+	ids := []string{}
+	for _, line := range listing {
+		if fields := strings.Fields(line); len(fields) > 0 {
+			ids = append(ids, fields[0])
+		}
+	}
+
+	for i := len(ids) - 1; i >= 0 && i >= len(ids)-maxPOP3Scan; i-- {
+		if _, err := cmd("TOP %s 0", ids[i]); err != nil {
+			quit()
+			return false, fmt.Errorf("pop3 top: %v", err)
+		}
+		headers, err := tp.ReadDotLines()
+		if err != nil {
+			return false, err
+		}
+		if !hasHeader(headers, emailCheckHeader, token) {
+			continue
+		}
+
+		if _, err := cmd("DELE %s", ids[i]); err != nil {
+			quit()
+			return true, fmt.Errorf("pop3 dele: %v", err)
+		}
+		return true, quit()
+	}
+
+	return false, quit()
+}
+
+func hasHeader(lines []string, name, value string) bool {
+	for _, line := range lines {
+		if line == "" {
+			break
+		}
+		i := strings.IndexByte(line, ':')
+		if i == -1 {
+			continue
+		}
+		if strings.EqualFold(line[:i], name) && strings.TrimSpace(line[i+1:]) == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+package healthchecks
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+func init() {
+	registerCheckType("postgres", newPostgresCheck)
+}
+
+// PostgresCheck pings a database and reports how close it is to running
+// out of connections, how far a replica lags and the oldest open
+// transaction.
+type PostgresCheck struct {
+	DSN string `json:"dsn"`
+
+	// SSLMode is passed through as sslmode, TLS as sslrootcert, sslcert
+	// and sslkey.
+	SSLMode string     `json:"ssl_mode"`
+	TLS     *TLSConfig `json:"tls"`
+
+	// Connections is in use over max_connections, 0 to 1.
+	Connections        *Threshold `json:"connections"`
+	ReplicationLag     *Threshold `json:"replication_lag_seconds"`
+	LongestTransaction *Threshold `json:"longest_transaction_seconds"`
+
+	db *sql.DB
+}
+
+func newPostgresCheck(raw json.RawMessage, cfg *ChecksConfig) (Checker, error) {
+	warning, critical := 0.8, 0.95
+	c := &PostgresCheck{
+		Connections: &Threshold{Warning: &warning, Critical: &critical},
+	}
+	if err := decodeConfig(raw, c); err != nil {
+		return nil, err
+	}
+	if c.DSN == "" {
+		return nil, errors.New("dsn is required")
+	}
+
+	params := map[string]string{}
+	if c.SSLMode != "" {
+		params["sslmode"] = c.SSLMode
+	}
+	if c.TLS != nil {
+		params["sslrootcert"] = c.TLS.CAFile
+		params["sslcert"] = c.TLS.CertFile
+		params["sslkey"] = c.TLS.KeyFile
+	}
+
+	dsn, err := withDSNParams(c.DSN, params)
+	if err != nil {
+		return nil, err
+	}
+
+	c.db, err = sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, err
+	}
+	c.db.SetMaxOpenConns(2)
+	c.db.SetConnMaxLifetime(5 * time.Minute)
+
+	return c, nil
+}
+
+// withDSNParams sets params on a postgres:// URL or key=value DSN, leaving
+// empty values out.
+func withDSNParams(dsn string, params map[string]string) (string, error) {
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		u, err := url.Parse(dsn)
+		if err != nil {
+			return "", err
+		}
+		q := u.Query()
+		for k, v := range params {
+			if v != "" {
+				q.Set(k, v)
+			}
+		}
+		u.RawQuery = q.Encode()
+		return u.String(), nil
+	}
+
+	for k, v := range params {
+		if v != "" {
+			dsn += " " + k + "='" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(v) + "'"
+		}
+	}
+
+	return dsn, nil
+}
+
+func (c *PostgresCheck) Check(ctx context.Context) *Result {
+	start := time.Now()
+	if err := c.db.PingContext(ctx); err != nil {
+		return &Result{Status: StatusDown, Message: err.Error()}
+	}
+
+	res := &Result{
+		Status:  StatusOperational,
+		Metrics: map[string]float64{"ping_ms": float64(time.Since(start).Milliseconds())},
+	}
+
+	var inUse, max float64
+	err := c.db.QueryRowContext(ctx,
+		`SELECT count(*), current_setting('max_connections')::int FROM pg_stat_activity`,
+	).Scan(&inUse, &max)
+	if err != nil {
+		return &Result{Status: StatusDown, Message: err.Error(), Metrics: res.Metrics}
+	}
+	res.Metrics["connections"] = inUse
+	res.Metrics["max_connections"] = max
+	if max > 0 {
+		res.Metrics["connection_ratio"] = inUse / max
+	}
+
+	var lag float64
+	err = c.db.QueryRowContext(ctx, `SELECT CASE WHEN pg_is_in_recovery()
+		THEN COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+		ELSE 0 END`,
+	).Scan(&lag)
+	if err != nil {
+		return &Result{Status: StatusDown, Message: err.Error(), Metrics: res.Metrics}
+	}
+	res.Metrics["replication_lag_seconds"] = lag
+
+	var longest float64
+	err = c.db.QueryRowContext(ctx, `SELECT COALESCE(EXTRACT(EPOCH FROM max(now() - xact_start)), 0)
+		FROM pg_stat_activity WHERE xact_start IS NOT NULL AND pid <> pg_backend_pid()`,
+	).Scan(&longest)
+	if err != nil {
+		return &Result{Status: StatusDown, Message: err.Error(), Metrics: res.Metrics}
+	}
+	res.Metrics["longest_transaction_seconds"] = longest
+
+	problems := []string{}
+	for _, m := range []struct {
+		name string
+		t    *Threshold
+	}{
+		{"connection_ratio", c.Connections},
+		{"replication_lag_seconds", c.ReplicationLag},
+		{"longest_transaction_seconds", c.LongestTransaction},
+	} {
+		v := res.Metrics[m.name]
+		if status := m.t.Evaluate(v); status != StatusOperational {
+			res.Status = worse(res.Status, status)
+			problems = append(problems, m.t.describe(m.name, v))
+		}
+	}
+	res.Message = strings.Join(problems, "; ")
+
+	return res
+}
@@ -0,0 +1,72 @@
+package healthchecks
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Scheduler runs each added Check on its own interval and hands the results
+// to Record.
+type Scheduler struct {
+	Record func(ctx context.Context, c *Check, res *Result)
+
+	mu      sync.Mutex
+	running map[string]*scheduled
+}
+
+type scheduled struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Add starts running c until ctx is done. A check already running under
+// the same name is replaced.
+func (s *Scheduler) Add(ctx context.Context, c *Check) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running == nil {
+		s.running = map[string]*scheduled{}
+	}
+	if r, ok := s.running[c.Name]; ok {
+		r.cancel()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	r := &scheduled{cancel: cancel, done: make(chan struct{})}
+	s.running[c.Name] = r
+
+	go s.run(ctx, c, r.done)
+}
+
+// Remove stops running the check named name, if it is, and waits for a run
+// in progress to be recorded so nothing is recorded for it afterwards.
+func (s *Scheduler) Remove(name string) {
+	s.mu.Lock()
+	r, ok := s.running[name]
+	delete(s.running, name)
+	s.mu.Unlock()
+
+	if ok {
+		r.cancel()
+		<-r.done
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, c *Check, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.Record(ctx, c, c.Run(ctx))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
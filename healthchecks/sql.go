@@ -0,0 +1,124 @@
+package healthchecks
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+func init() {
+	registerCheckType("sql", newSQLCheck)
+}
+
+// DataSource is a database named in the checks file.
+type DataSource struct {
+	Driver  string     `json:"driver"`
+	DSN     string     `json:"dsn"`
+	SSLMode string     `json:"ssl_mode"`
+	TLS     *TLSConfig `json:"tls"`
+
+	db *sql.DB
+}
+
+// DB opens the named data source, sharing one pool between the checks
+// that use it.
+func (cfg *ChecksConfig) DB(name string) (*sql.DB, error) {
+	ds, ok := cfg.DataSources[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown datasource %q", name)
+	}
+	if ds.db != nil {
+		return ds.db, nil
+	}
+
+	if ds.Driver == "" {
+		ds.Driver = "postgres"
+	}
+
+	dsn := ds.DSN
+	if ds.Driver == "postgres" {
+		params := map[string]string{"sslmode": ds.SSLMode}
+		if ds.TLS != nil {
+			params["sslrootcert"] = ds.TLS.CAFile
+			params["sslcert"] = ds.TLS.CertFile
+			params["sslkey"] = ds.TLS.KeyFile
+		}
+
+		var err error
+		if dsn, err = withDSNParams(dsn, params); err != nil {
+			return nil, fmt.Errorf("datasource %s: %v", name, err)
+		}
+	}
+
+	db, err := sql.Open(ds.Driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("datasource %s: %v", name, err)
+	}
+	db.SetMaxOpenConns(2)
+	db.SetConnMaxLifetime(5 * time.Minute)
+	ds.db = db
+
+	return db, nil
+}
+
+// SQLCheck runs Query read only against DataSource and compares the single
+// number it returns with Threshold, e.g. orders pending for more than two
+// hours going down at 5.
+type SQLCheck struct {
+	DataSource string     `json:"datasource"`
+	Query      string     `json:"query"`
+	Threshold  *Threshold `json:"threshold"`
+
+	db *sql.DB
+}
+
+func newSQLCheck(raw json.RawMessage, cfg *ChecksConfig) (Checker, error) {
+	c := &SQLCheck{}
+	if err := decodeConfig(raw, c); err != nil {
+		return nil, err
+	}
+	if c.Query == "" {
+		return nil, errors.New("query is required")
+	}
+	if c.Threshold == nil {
+		return nil, errors.New("threshold is required")
+	}
+
+	db, err := cfg.DB(c.DataSource)
+	if err != nil {
+		return nil, err
+	}
+	c.db = db
+
+	return c, nil
+}
+
+func (c *SQLCheck) Check(ctx context.Context) *Result {
+	tx, err := c.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return &Result{Status: StatusUnknown, Message: err.Error()}
+	}
+	defer tx.Rollback()
+
+	var value sql.NullFloat64
+	if err := tx.QueryRowContext(ctx, c.Query).Scan(&value); err != nil {
+		return &Result{Status: StatusUnknown, Message: err.Error()}
+	}
+	if !value.Valid {
+		return &Result{Status: StatusUnknown, Message: "query returned null"}
+	}
+
+	res := &Result{
+		Status:  c.Threshold.Evaluate(value.Float64),
+		Metrics: map[string]float64{"value": value.Float64},
+		Message: fmt.Sprintf("value %g", value.Float64),
+	}
+	if res.Status != StatusOperational {
+		res.Message = c.Threshold.describe("value", value.Float64)
+	}
+
+	return res
+}
@@ -0,0 +1,178 @@
+package healthchecks
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/option"
+)
+
+func init() {
+	registerCheckType("storage", newStorageCheck)
+}
+
+// ObjectStore is the little of a bucket the storage check needs.
+type ObjectStore interface {
+	Write(ctx context.Context, name string, data []byte) error
+	Read(ctx context.Context, name string) ([]byte, error)
+	Delete(ctx context.Context, name string) error
+}
+
+type gcsStore struct {
+	bucket *storage.BucketHandle
+
+	// the client library reads over https, not the endpoint's scheme,
+	// unless STORAGE_EMULATOR_HOST is set, so with an endpoint objects are
+	// downloaded through its JSON API instead
+	name     string
+	endpoint string
+}
+
+func (s *gcsStore) Write(ctx context.Context, name string, data []byte) error {
+	w := s.bucket.Object(name).NewWriter(ctx)
+	if _, err := w.Write(data); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+func (s *gcsStore) Read(ctx context.Context, name string) ([]byte, error) {
+	if s.endpoint != "" {
+		return s.download(ctx, name)
+	}
+
+	r, err := s.bucket.Object(name).NewReader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
+
+func (s *gcsStore) download(ctx context.Context, name string) ([]byte, error) {
+	u := s.endpoint + "b/" + url.PathEscape(s.name) + "/o/" + url.PathEscape(name) + "?alt=media"
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download %s: %s", name, resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+func (s *gcsStore) Delete(ctx context.Context, name string) error {
+	return s.bucket.Object(name).Delete(ctx)
+}
+
+// StorageCheck writes a canary object to Bucket, reads it back and deletes
+// it. Each run uses a new object so replicas don't trip over each other.
+type StorageCheck struct {
+	Bucket string `json:"bucket"`
+	Prefix string `json:"prefix"`
+	Size   int    `json:"size"`
+
+	// Endpoint overrides the GCS JSON API, e.g.
+	// http://localhost:4443/storage/v1/ for an emulator, unauthenticated.
+	Endpoint string `json:"endpoint"`
+
+	store ObjectStore
+}
+
+func newStorageCheck(raw json.RawMessage, cfg *ChecksConfig) (Checker, error) {
+	c := &StorageCheck{Prefix: "status-dashboard/canary-", Size: 1024}
+	if err := decodeConfig(raw, c); err != nil {
+		return nil, err
+	}
+	if c.Bucket == "" {
+		return nil, errors.New("bucket is required")
+	}
+	if c.Size <= 0 {
+		return nil, errors.New("size must be positive")
+	}
+
+	opts := []option.ClientOption{}
+	if c.Endpoint != "" {
+		if !strings.HasSuffix(c.Endpoint, "/") {
+			c.Endpoint += "/"
+		}
+		if _, err := url.Parse(c.Endpoint); err != nil {
+			return nil, fmt.Errorf("endpoint: %v", err)
+		}
+		opts = append(opts, option.WithEndpoint(c.Endpoint), option.WithoutAuthentication())
+	}
+	client, err := storage.NewClient(context.Background(), opts...)
+	if err != nil {
+		return nil, err
+	}
+	c.store = &gcsStore{bucket: client.Bucket(c.Bucket), name: c.Bucket, endpoint: c.Endpoint}
+
+	return c, nil
+}
+
+func (c *StorageCheck) Check(ctx context.Context) *Result {
+	suffix := make([]byte, 4)
+	data := make([]byte, c.Size)
+	for _, b := range [][]byte{suffix, data} {
+		if _, err := rand.Read(b); err != nil {
+			return &Result{Status: StatusUnknown, Message: err.Error()}
+		}
+	}
+	name := fmt.Sprintf("%s%d-%s", c.Prefix, time.Now().Unix(), hex.EncodeToString(suffix))
+
+	metrics := map[string]float64{}
+	step := func(metric string, f func() error) error {
+		start := time.Now()
+		err := f()
+		metrics[metric] = float64(time.Since(start).Milliseconds())
+		return err
+	}
+
+	if err := step("write_ms", func() error { return c.store.Write(ctx, name, data) }); err != nil {
+		return &Result{Status: StatusDown, Message: "write: " + err.Error(), Metrics: metrics}
+	}
+
+	var got []byte
+	readErr := step("read_ms", func() (err error) {
+		got, err = c.store.Read(ctx, name)
+		return err
+	})
+
+	// clean up whatever the read found
+	deleteErr := step("delete_ms", func() error { return c.store.Delete(ctx, name) })
+
+	res := &Result{Status: StatusOperational, Message: "wrote, read and deleted " + name, Metrics: metrics}
+	switch {
+	case readErr != nil:
+		res.Status = StatusDown
+		res.Message = "read: " + readErr.Error()
+	case !bytes.Equal(got, data):
+		res.Status = StatusDown
+		res.Message = fmt.Sprintf("read back %d bytes that differ from the %d written", len(got), len(data))
+	case deleteErr != nil:
+		// reads and writes work, but canaries are piling up
+		res.Status = StatusDegraded
+		res.Message = "delete: " + deleteErr.Error()
+	}
+
+	return res
+}
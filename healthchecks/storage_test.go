@@ -0,0 +1,167 @@
+package healthchecks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeGCS serves the GCS JSON API calls the storage check makes, for one
+// bucket.
+type fakeGCS struct {
+	bucket string
+
+	mu          sync.Mutex
+	objects     map[string][]byte
+	failWrite   bool
+	corruptRead bool
+	failDelete  bool
+}
+
+func (f *fakeGCS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	path := r.URL.EscapedPath()
+	upload := "/upload/storage/v1/b/" + f.bucket + "/o"
+	object := "/storage/v1/b/" + f.bucket + "/o/"
+
+	switch {
+	case r.Method == "POST" && path == upload:
+		if f.failWrite {
+			http.Error(w, `{"error": {"code": 403, "message": "forbidden"}}`, http.StatusForbidden)
+			return
+		}
+		name, data, err := readUpload(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.objects[name] = data
+		json.NewEncoder(w).Encode(map[string]string{"bucket": f.bucket, "name": name, "size": fmt.Sprint(len(data))})
+
+	case strings.HasPrefix(path, object):
+		name, err := url.PathUnescape(strings.TrimPrefix(path, object))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		data, ok := f.objects[name]
+		if !ok {
+			http.Error(w, `{"error": {"code": 404, "message": "not found"}}`, http.StatusNotFound)
+			return
+		}
+
+		switch {
+		case r.Method == "GET" && r.URL.Query().Get("alt") == "media":
+			if f.corruptRead {
+				data = data[1:]
+			}
+			w.Write(data)
+		case r.Method == "DELETE":
+			if f.failDelete {
+				http.Error(w, `{"error": {"code": 403, "message": "forbidden"}}`, http.StatusForbidden)
+				return
+			}
+			delete(f.objects, name)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(w, "unexpected "+r.Method, http.StatusMethodNotAllowed)
+		}
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// readUpload reads a multipart upload, the object's metadata then its data.
+func readUpload(r *http.Request) (string, []byte, error) {
+	_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return "", nil, err
+	}
+	mr := multipart.NewReader(r.Body, params["boundary"])
+
+	part, err := mr.NextPart()
+	if err != nil {
+		return "", nil, err
+	}
+	meta := struct {
+		Name string `json:"name"`
+	}{}
+	if err := json.NewDecoder(part).Decode(&meta); err != nil {
+		return "", nil, err
+	}
+
+	part, err = mr.NextPart()
+	if err != nil {
+		return "", nil, err
+	}
+	data, err := ioutil.ReadAll(part)
+	return meta.Name, data, err
+}
+
+func TestStorageCheck(t *testing.T) {
+	tests := []struct {
+		name   string
+		gcs    *fakeGCS
+		size   int
+		status string
+		left   int
+	}{
+		{name: "round trip", gcs: &fakeGCS{}, status: StatusOperational},
+		{name: "one byte object", gcs: &fakeGCS{}, size: 1, status: StatusOperational},
+		{name: "write fails", gcs: &fakeGCS{failWrite: true}, status: StatusDown},
+		{name: "read differs", gcs: &fakeGCS{corruptRead: true}, status: StatusDown},
+		{name: "delete fails", gcs: &fakeGCS{failDelete: true}, status: StatusDegraded, left: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.gcs.bucket = "canaries"
+			tt.gcs.objects = map[string][]byte{}
+			srv := httptest.NewServer(tt.gcs)
+			defer srv.Close()
+
+			size := tt.size
+			if size == 0 {
+				size = 1024
+			}
+			config := fmt.Sprintf(`{"bucket": "canaries", "endpoint": %q, "size": %d}`, srv.URL+"/storage/v1", size)
+			c, err := checkTypes["storage"](json.RawMessage(config), &ChecksConfig{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res := c.Check(context.Background())
+			if res.Status != tt.status {
+				t.Fatalf("status = %s, want %s: %s", res.Status, tt.status, res.Message)
+			}
+			for _, m := range []string{"write_ms", "read_ms", "delete_ms"} {
+				if _, ok := res.Metrics[m]; !ok && tt.status != StatusDown {
+					t.Errorf("no %s metric", m)
+				}
+			}
+
+			tt.gcs.mu.Lock()
+			defer tt.gcs.mu.Unlock()
+			if len(tt.gcs.objects) != tt.left {
+				t.Errorf("%d objects left, want %d", len(tt.gcs.objects), tt.left)
+			}
+			for name := range tt.gcs.objects {
+				if !strings.HasPrefix(name, "status-dashboard/canary-") {
+					t.Errorf("object %s is missing the prefix", name)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package healthchecks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"time"
+)
+
+func init() {
+	registerCheckType("tcp", newTCPCheck)
+}
+
+// TCPCheck connects to Address. When Send is set it is written once
+// connected, and when Expect is set the first bytes read back (a banner
+// if nothing was sent) must match it.
+type TCPCheck struct {
+	Address string `json:"address"`
+	Send    string `json:"send"`
+	Expect  string `json:"expect"`
+
+	expect *regexp.Regexp
+}
+
+// at most this much of the reply is matched against Expect
+const maxBannerSize = 4096
+
+func newTCPCheck(raw json.RawMessage, cfg *ChecksConfig) (Checker, error) {
+	c := &TCPCheck{}
+	if err := decodeConfig(raw, c); err != nil {
+		return nil, err
+	}
+	if _, _, err := net.SplitHostPort(c.Address); err != nil {
+		return nil, fmt.Errorf("address must be host:port: %v", err)
+	}
+
+	if c.Expect != "" {
+		re, err := regexp.Compile(c.Expect)
+		if err != nil {
+			return nil, fmt.Errorf("expect: %v", err)
+		}
+		c.expect = re
+	}
+
+	return c, nil
+}
+
+func (c *TCPCheck) Check(ctx context.Context) *Result {
+	dialer := &net.Dialer{}
+
+	start := time.Now()
+	conn, err := dialer.DialContext(ctx, "tcp", c.Address)
+	if err != nil {
+		return &Result{Status: StatusDown, Message: err.Error()}
+	}
+	defer conn.Close()
+	connect := time.Since(start)
+
+	res := &Result{
+		Status:  StatusOperational,
+		Message: "connected to " + c.Address,
+		Metrics: map[string]float64{"connect_ms": float64(connect.Milliseconds())},
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	if c.Send != "" {
+		if _, err := conn.Write([]byte(c.Send)); err != nil {
+			res.Status = StatusDown
+			res.Message = "send: " + err.Error()
+			return res
+		}
+	}
+
+	if c.expect == nil {
+		return res
+	}
+
+	reply, err := readUntilMatch(conn, c.expect)
+	res.Metrics["response_ms"] = float64(time.Since(start).Milliseconds())
+	if err != nil {
+		res.Status = StatusDown
+		res.Message = fmt.Sprintf("response %q did not match %q: %v", truncate(string(reply), 200), c.Expect, err)
+		return res
+	}
+
+	return res
+}
+
+// readUntilMatch reads from conn until what has arrived matches re, the
+// connection closes, or the deadline passes.
+func readUntilMatch(conn net.Conn, re *regexp.Regexp) ([]byte, error) {
+	buf := make([]byte, 0, maxBannerSize)
+	chunk := make([]byte, 512)
+
+	for len(buf) < maxBannerSize {
+		n, err := conn.Read(chunk)
+		buf = append(buf, chunk[:n]...)
+		if re.Match(buf) {
+			return buf, nil
+		}
+		if err != nil {
+			return buf, err
+		}
+	}
+
+	return buf, errors.New("no match in the first 4096 bytes")
+}
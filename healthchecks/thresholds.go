@@ -0,0 +1,66 @@
+package healthchecks
+
+import "fmt"
+
+// Threshold degrades a check when a value reaches Warning and takes it
+// down at Critical. Higher values are worse unless Below is set.
+type Threshold struct {
+	Warning  *float64 `json:"warning"`
+	Critical *float64 `json:"critical"`
+	Below    bool     `json:"below"`
+}
+
+func (t *Threshold) Evaluate(v float64) string {
+	if t == nil {
+		return StatusOperational
+	}
+
+	breached := func(limit *float64) bool {
+		if limit == nil {
+			return false
+		}
+		if t.Below {
+			return v <= *limit
+		}
+		return v >= *limit
+	}
+
+	switch {
+	case breached(t.Critical):
+		return StatusDown
+	case breached(t.Warning):
+		return StatusDegraded
+	}
+
+	return StatusOperational
+}
+
+// describe says which limit v breached for a check message.
+func (t *Threshold) describe(name string, v float64) string {
+	limit := t.Warning
+	if t.Evaluate(v) == StatusDown {
+		limit = t.Critical
+	}
+
+	op := ">="
+	if t.Below {
+		op = "<="
+	}
+
+	return fmt.Sprintf("%s %g %s %g", name, v, op, *limit)
+}
+
+var severity = map[string]int{
+	StatusOperational: 0,
+	StatusDegraded:    1,
+	StatusUnknown:     2,
+	StatusDown:        3,
+}
+
+// worse is whichever of two statuses is more severe.
+func worse(a, b string) string {
+	if severity[b] > severity[a] {
+		return b
+	}
+	return a
+}
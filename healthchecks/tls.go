@@ -0,0 +1,135 @@
+package healthchecks
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"time"
+)
+
+func init() {
+	registerCheckType("tls", newCertificateCheck)
+}
+
+// CertificateCheck reports how long the certificate served on Host has left
+// and whether it is valid for the host name and chains to a trusted root.
+type CertificateCheck struct {
+	Host       string `json:"host"`
+	Port       int    `json:"port"`
+	ServerName string `json:"server_name"`
+
+	// WarningDays is when an otherwise valid certificate goes degraded.
+	WarningDays int `json:"warning_days"`
+
+	// TLS adds trusted roots for the chain check and a client certificate
+	// for servers that require one.
+	TLS *TLSConfig `json:"tls"`
+
+	tlsConfig *tls.Config
+	roots     *x509.CertPool
+}
+
+func newCertificateCheck(raw json.RawMessage, cfg *ChecksConfig) (Checker, error) {
+	c := &CertificateCheck{Port: 443, WarningDays: 14}
+	if err := decodeConfig(raw, c); err != nil {
+		return nil, err
+	}
+	if c.Host == "" {
+		return nil, errors.New("host is required")
+	}
+	if c.ServerName == "" && c.TLS != nil {
+		c.ServerName = c.TLS.ServerName
+	}
+	if c.ServerName == "" {
+		c.ServerName = c.Host
+	}
+
+	var err error
+	if c.tlsConfig, err = c.TLS.Build(); err != nil {
+		return nil, err
+	}
+	if c.roots, err = c.TLS.roots(); err != nil {
+		return nil, err
+	}
+
+	// verification is done in Check so a bad certificate can be told
+	// apart from an unreachable host
+	c.tlsConfig.ServerName = c.ServerName
+	c.tlsConfig.InsecureSkipVerify = true
+
+	return c, nil
+}
+
+func (c *CertificateCheck) Check(ctx context.Context) *Result {
+	addr := net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
+
+	dialer := &net.Dialer{}
+	if deadline, ok := ctx.Deadline(); ok {
+		dialer.Deadline = deadline
+	}
+
+	conn, err := tls.DialWithDialer(dialer, "tcp", addr, c.tlsConfig)
+	if err != nil {
+		return &Result{Status: StatusDown, Message: err.Error()}
+	}
+	defer conn.Close()
+
+	certs := conn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return &Result{Status: StatusDown, Message: "no certificate presented"}
+	}
+	leaf := certs[0]
+
+	days := math.Floor(time.Until(leaf.NotAfter).Hours() / 24)
+	res := &Result{
+		Status:  StatusOperational,
+		Metrics: map[string]float64{"days_until_expiry": days},
+		Message: fmt.Sprintf("certificate for %s expires %s", leaf.Subject.CommonName, leaf.NotAfter.UTC().Format(time.RFC3339)),
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err = leaf.Verify(x509.VerifyOptions{
+		DNSName:       c.ServerName,
+		Roots:         c.roots,
+		Intermediates: intermediates,
+	})
+	if err != nil {
+		res.Status = StatusDown
+		res.Message = certificateProblem(err)
+		return res
+	}
+
+	if days < float64(c.WarningDays) {
+		res.Status = StatusDegraded
+		res.Message = fmt.Sprintf("certificate expires in %.0f days", days)
+	}
+
+	return res
+}
+
+func certificateProblem(err error) string {
+	var hostErr x509.HostnameError
+	var authErr x509.UnknownAuthorityError
+	var invalidErr x509.CertificateInvalidError
+
+	switch {
+	case errors.As(err, &hostErr):
+		return "hostname mismatch: " + err.Error()
+	case errors.As(err, &authErr):
+		return "untrusted chain: " + err.Error()
+	case errors.As(err, &invalidErr) && invalidErr.Reason == x509.Expired:
+		return "certificate expired or not yet valid: " + err.Error()
+	}
+
+	return "invalid chain: " + err.Error()
+}
@@ -0,0 +1,90 @@
+package healthchecks
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// TLSConfig is how a check or client sets up TLS. Certificates are
+// verified unless InsecureSkipVerify is set.
+type TLSConfig struct {
+	// CAFile is a PEM bundle trusted in addition to the system roots.
+	CAFile string `json:"ca_file"`
+
+	// CertFile and KeyFile are a client certificate for mTLS.
+	CertFile string `json:"cert_file"`
+	KeyFile  string `json:"key_file"`
+
+	ServerName         string `json:"server_name"`
+	InsecureSkipVerify bool   `json:"insecure_skip_verify"`
+}
+
+func (t *TLSConfig) Build() (*tls.Config, error) {
+	cfg := &tls.Config{}
+	if t == nil {
+		return cfg, nil
+	}
+
+	cfg.ServerName = t.ServerName
+	cfg.InsecureSkipVerify = t.InsecureSkipVerify
+
+	if t.CAFile != "" {
+		pool, err := t.roots()
+		if err != nil {
+			return nil, err
+		}
+		cfg.RootCAs = pool
+	}
+
+	if t.CertFile != "" || t.KeyFile != "" {
+		if t.CertFile == "" || t.KeyFile == "" {
+			return nil, errors.New("tls: cert_file and key_file must be set together")
+		}
+		cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("tls: %v", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+// roots is the system pool plus CAFile, nil meaning the system pool alone.
+func (t *TLSConfig) roots() (*x509.CertPool, error) {
+	if t == nil || t.CAFile == "" {
+		return nil, nil
+	}
+
+	pem, err := ioutil.ReadFile(t.CAFile)
+	if err != nil {
+		return nil, fmt.Errorf("tls: %v", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("tls: no certificates found in %s", t.CAFile)
+	}
+
+	return pool, nil
+}
+
+// NewTransport is an http.Transport using t.
+func NewTransport(t *TLSConfig) (*http.Transport, error) {
+	cfg, err := t.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = cfg
+
+	return transport, nil
+}
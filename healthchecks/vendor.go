@@ -0,0 +1,183 @@
+package healthchecks
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokens are refreshed this long before the vendor says they expire
+const tokenExpiryMargin = time.Minute
+
+const (
+	minBackoff = time.Minute
+	maxBackoff = time.Hour
+)
+
+var errThrottled = errors.New("vendor request budget exhausted or backing off")
+
+// vendor is the state a vendor probe keeps between cycles: its cached auth
+// token, any backoff the vendor asked for and its hourly request budget.
+type vendor struct {
+	name   string
+	budget int
+
+	mu          sync.Mutex
+	token       string
+	expires     time.Time
+	authStatus  *ServiceResponse
+	last        *ServiceResponse
+	backoff     time.Time
+	strikes     uint
+	windowStart time.Time
+	used        int
+}
+
+func (c *Client) vendor(name string, budget int) *vendor {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.vendors == nil {
+		c.vendors = map[string]*vendor{}
+	}
+
+	v, ok := c.vendors[name]
+	if !ok {
+		v = &vendor{name: name, budget: budget}
+		c.vendors[name] = v
+	}
+
+	return v
+}
+
+// allow spends one request from the budget unless the vendor is backing off
+// or the budget for the current hour is used up.
+func (v *vendor) allow(now time.Time) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if now.Before(v.backoff) {
+		return false
+	}
+
+	if v.budget > 0 {
+		if now.Sub(v.windowStart) >= time.Hour {
+			v.windowStart = now
+			v.used = 0
+		}
+		if v.used >= v.budget {
+			return false
+		}
+	}
+
+	v.used++
+	return true
+}
+
+// do sends req if the vendor may be asked right now. A 429 puts the vendor
+// into backoff for as long as its Retry-After asks, or exponentially longer
+// on each consecutive 429 when it doesn't say.
+func (v *vendor) do(req *http.Request) (*http.Response, error) {
+	now := time.Now()
+	if !v.allow(now) {
+		return nil, errThrottled
+	}
+
+	internalClient := &http.Client{}
+
+	resp, err := internalClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if resp.StatusCode != http.StatusTooManyRequests {
+		v.strikes = 0
+		return resp, nil
+	}
+
+	wait, ok := retryAfter(resp.Header.Get("Retry-After"), now)
+	if !ok {
+		wait = minBackoff << v.strikes
+		if wait > maxBackoff || wait <= 0 {
+			wait = maxBackoff
+		}
+		v.strikes++
+	}
+	v.backoff = now.Add(wait)
+
+	return resp, nil
+}
+
+func retryAfter(header string, now time.Time) (time.Duration, bool) {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(header); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(header); err == nil {
+		if t.Before(now) {
+			return 0, true
+		}
+		return t.Sub(now), true
+	}
+
+	return 0, false
+}
+
+func (v *vendor) cachedToken(now time.Time) (string, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.token == "" || !now.Before(v.expires) {
+		return "", false
+	}
+
+	return v.token, true
+}
+
+func (v *vendor) setToken(token string, ttl time.Duration, status *ServiceResponse) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.token = token
+	v.expires = time.Now().Add(ttl - tokenExpiryMargin)
+	v.authStatus = status
+}
+
+func (v *vendor) remember(status *ServiceResponse) *ServiceResponse {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.last = status
+	return status
+}
+
+// lastStatus is reported while the vendor can't be asked. Being throttled
+// isn't an outage, so the previous result stands until we can look again.
+func (v *vendor) lastStatus(fallback *ServiceResponse) *ServiceResponse {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.last != nil {
+		return v.last
+	}
+
+	return fallback
+}
+
+func (v *vendor) cachedAuthStatus() *ServiceResponse {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	return v.authStatus
+}
@@ -0,0 +1,48 @@
+package healthchecks
+
+// Version is the build a service says it is running, from its health
+// response body or headers.
+type Version struct {
+	Version string `json:"version"`
+	Commit  string `json:"commit"`
+	Build   string `json:"build"`
+}
+
+var versionHeaders = map[string][]string{
+	"version": {"X-Version", "X-App-Version", "X-Service-Version"},
+	"commit":  {"X-Commit", "X-Git-Commit", "X-Release-Id"},
+	"build":   {"X-Build", "X-Build-Id"},
+}
+
+// BuildInfo is the version the response reports, nil if it doesn't. The
+// body wins over headers, and an IETF releaseId stands in for a commit.
+func (s *ServiceResponse) BuildInfo() *Version {
+	v := &Version{
+		Version: s.Version,
+		Commit:  s.Commit,
+		Build:   s.Build,
+	}
+	if v.Commit == "" {
+		v.Commit = s.ReleaseId
+	}
+
+	fromHeader := func(field *string, names []string) {
+		for _, name := range names {
+			if *field != "" {
+				return
+			}
+			*field = s.header.Get(name)
+		}
+	}
+	if s.header != nil {
+		fromHeader(&v.Version, versionHeaders["version"])
+		fromHeader(&v.Commit, versionHeaders["commit"])
+		fromHeader(&v.Build, versionHeaders["build"])
+	}
+
+	if v.Version == "" && v.Commit == "" && v.Build == "" {
+		return nil
+	}
+
+	return v
+}
@@ -0,0 +1,175 @@
+package healthchecks
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+func init() {
+	registerCheckType("websocket", newWebSocketCheck)
+}
+
+// WebSocketCheck opens a connection to URL, a ws:// or wss:// address. When
+// Send is set it is sent as a text message, and when Expect is set a
+// message matching it must come back before the timeout. Other messages
+// the server pushes in the meantime are skipped.
+type WebSocketCheck struct {
+	URL     string            `json:"url"`
+	Origin  string            `json:"origin"`
+	Headers map[string]string `json:"headers"`
+	Send    string            `json:"send"`
+	Expect  string            `json:"expect"`
+	TLS     *TLSConfig        `json:"tls"`
+
+	location  *url.URL
+	expect    *regexp.Regexp
+	tlsConfig *tls.Config
+}
+
+func newWebSocketCheck(raw json.RawMessage, cfg *ChecksConfig) (Checker, error) {
+	c := &WebSocketCheck{}
+	if err := decodeConfig(raw, c); err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(c.URL)
+	if err != nil {
+		return nil, fmt.Errorf("url: %v", err)
+	}
+	if u.Scheme != "ws" && u.Scheme != "wss" {
+		return nil, fmt.Errorf("url must be ws:// or wss://, got %q", c.URL)
+	}
+	c.location = u
+
+	// browsers always send one and servers often insist on it
+	if c.Origin == "" {
+		origin := *u
+		origin.Scheme = "http"
+		if u.Scheme == "wss" {
+			origin.Scheme = "https"
+		}
+		origin.Path, origin.RawQuery = "", ""
+		c.Origin = origin.String()
+	}
+
+	if c.Expect != "" {
+		re, err := regexp.Compile(c.Expect)
+		if err != nil {
+			return nil, fmt.Errorf("expect: %v", err)
+		}
+		c.expect = re
+	}
+
+	if u.Scheme == "wss" {
+		tlsConfig, err := c.TLS.Build()
+		if err != nil {
+			return nil, err
+		}
+		if tlsConfig.ServerName == "" {
+			tlsConfig.ServerName = u.Hostname()
+		}
+		c.tlsConfig = tlsConfig
+	}
+
+	return c, nil
+}
+
+func (c *WebSocketCheck) Check(ctx context.Context) *Result {
+	config, err := websocket.NewConfig(c.URL, c.Origin)
+	if err != nil {
+		return &Result{Status: StatusUnknown, Message: err.Error()}
+	}
+	for k, v := range c.Headers {
+		config.Header.Set(k, v)
+	}
+
+	start := time.Now()
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return &Result{Status: StatusDown, Message: err.Error()}
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	ws, err := websocket.NewClient(config, conn)
+	if err != nil {
+		return &Result{Status: StatusDown, Message: "handshake: " + err.Error()}
+	}
+	defer ws.Close()
+
+	res := &Result{
+		Status:  StatusOperational,
+		Message: "connected to " + c.URL,
+		Metrics: map[string]float64{"handshake_ms": float64(time.Since(start).Milliseconds())},
+	}
+
+	sent := time.Now()
+	if c.Send != "" {
+		if err := websocket.Message.Send(ws, c.Send); err != nil {
+			res.Status = StatusDown
+			res.Message = "send: " + err.Error()
+			return res
+		}
+	}
+
+	if c.expect == nil {
+		return res
+	}
+
+	for {
+		var reply string
+		if err := websocket.Message.Receive(ws, &reply); err != nil {
+			res.Status = StatusDown
+			res.Message = fmt.Sprintf("no reply matching %q: %v", c.Expect, err)
+			return res
+		}
+		if c.expect.MatchString(reply) {
+			res.Metrics["round_trip_ms"] = float64(time.Since(sent).Milliseconds())
+			return res
+		}
+	}
+}
+
+// dial opens the connection itself so the handshake is bound by ctx, which
+// websocket.DialConfig has no way to take.
+func (c *WebSocketCheck) dial(ctx context.Context) (net.Conn, error) {
+	host := c.location.Host
+	if c.location.Port() == "" {
+		port := "80"
+		if c.location.Scheme == "wss" {
+			port = "443"
+		}
+		host = net.JoinHostPort(c.location.Hostname(), port)
+	}
+
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return nil, err
+	}
+	if c.tlsConfig == nil {
+		return conn, nil
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	tlsConn := tls.Client(conn, c.tlsConfig)
+	if err := tlsConn.Handshake(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return tlsConn, nil
+}
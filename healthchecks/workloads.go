@@ -0,0 +1,168 @@
+package healthchecks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+func init() {
+	registerCheckType("kubernetes", newKubernetesCheck)
+}
+
+// KubernetesCheck looks at the Deployments, StatefulSets and Pods matching
+// Selector in Namespaces. Workloads with no replicas available are down,
+// and ones short of replicas, crash looping, restarting within
+// RestartWindow or stuck pending past PendingGrace are degraded. Each
+// workload, and each pod with a problem, is reported as a component.
+type KubernetesCheck struct {
+	Namespaces    []string   `json:"namespaces"`
+	Selector      string     `json:"selector"`
+	RestartWindow Duration   `json:"restart_window"`
+	PendingGrace  Duration   `json:"pending_grace"`
+	Restarts      *Threshold `json:"restarts"`
+
+	client KubeClient
+}
+
+func newKubernetesCheck(raw json.RawMessage, cfg *ChecksConfig) (Checker, error) {
+	one := 1.0
+	c := &KubernetesCheck{
+		RestartWindow: Duration{15 * time.Minute},
+		PendingGrace:  Duration{5 * time.Minute},
+		Restarts:      &Threshold{Warning: &one},
+	}
+	if err := decodeConfig(raw, c); err != nil {
+		return nil, err
+	}
+	if len(c.Namespaces) == 0 {
+		return nil, errors.New("namespaces is required")
+	}
+
+	client, err := cfg.Kube()
+	if err != nil {
+		return nil, err
+	}
+	c.client = client
+
+	return c, nil
+}
+
+func (c *KubernetesCheck) Check(ctx context.Context) *Result {
+	res := &Result{Status: StatusOperational, Components: []*Component{}}
+	problems := []string{}
+	var workloads, unavailable, crashLooping, restarts, pending int
+
+	add := func(name, status, output string) {
+		res.Components = append(res.Components, &Component{Name: name, Status: status, Output: output})
+		res.Status = worse(res.Status, status)
+		if status != StatusOperational {
+			problems = append(problems, name+": "+output)
+		}
+	}
+
+	now := time.Now()
+	for _, ns := range c.Namespaces {
+		deployments, err := c.client.Deployments(ctx, ns, c.Selector)
+		if err != nil {
+			return &Result{Status: StatusUnknown, Message: err.Error()}
+		}
+		for _, d := range deployments {
+			workloads++
+			want := replicas(d.Spec.Replicas)
+			missing := want - d.Status.AvailableReplicas
+			if d.Status.UnavailableReplicas > missing {
+				missing = d.Status.UnavailableReplicas
+			}
+			if missing > 0 {
+				unavailable += missing
+			}
+			add("deployment/"+ns+"/"+d.Metadata.Name, replicaStatus(d.Status.AvailableReplicas, want, missing),
+				fmt.Sprintf("%d/%d available", d.Status.AvailableReplicas, want))
+		}
+
+		sets, err := c.client.StatefulSets(ctx, ns, c.Selector)
+		if err != nil {
+			return &Result{Status: StatusUnknown, Message: err.Error()}
+		}
+		for _, s := range sets {
+			workloads++
+			want := replicas(s.Spec.Replicas)
+			missing := want - s.Status.ReadyReplicas
+			if missing > 0 {
+				unavailable += missing
+			}
+			add("statefulset/"+ns+"/"+s.Metadata.Name, replicaStatus(s.Status.ReadyReplicas, want, missing),
+				fmt.Sprintf("%d/%d ready", s.Status.ReadyReplicas, want))
+		}
+
+		pods, err := c.client.Pods(ctx, ns, c.Selector)
+		if err != nil {
+			return &Result{Status: StatusUnknown, Message: err.Error()}
+		}
+		for _, p := range pods {
+			issues := []string{}
+			status := StatusOperational
+
+			if p.Status.Phase == "Pending" && now.Sub(p.Metadata.CreationTimestamp) > c.PendingGrace.Duration {
+				pending++
+				status = StatusDegraded
+				issues = append(issues, "pending since "+p.Metadata.CreationTimestamp.UTC().Format(time.RFC3339))
+			}
+
+			for _, cs := range p.Status.ContainerStatuses {
+				if w := cs.State.Waiting; w != nil && w.Reason == "CrashLoopBackOff" {
+					crashLooping++
+					status = StatusDegraded
+					issues = append(issues, cs.Name+" in CrashLoopBackOff")
+				}
+				if t := cs.LastState.Terminated; t != nil && now.Sub(t.FinishedAt) < c.RestartWindow.Duration {
+					restarts++
+					issues = append(issues, fmt.Sprintf("%s restarted at %s (%s, exit %d)",
+						cs.Name, t.FinishedAt.UTC().Format(time.RFC3339), t.Reason, t.ExitCode))
+				}
+			}
+
+			// only pods with something to say, there can be hundreds. A
+			// restart alone counts against the Restarts threshold below.
+			if len(issues) > 0 {
+				add("pod/"+ns+"/"+p.Metadata.Name, status, strings.Join(issues, ", "))
+			}
+		}
+	}
+
+	res.Metrics = map[string]float64{
+		"unavailable_replicas": float64(unavailable),
+		"crash_looping":        float64(crashLooping),
+		"restarts":             float64(restarts),
+		"pending_pods":         float64(pending),
+	}
+
+	if s := c.Restarts.Evaluate(float64(restarts)); s != StatusOperational {
+		problems = append(problems, c.Restarts.describe("restarts", float64(restarts)))
+		res.Status = worse(res.Status, s)
+	}
+
+	if len(problems) == 0 {
+		res.Message = fmt.Sprintf("%d workloads healthy", workloads)
+	} else {
+		res.Message = truncate(strings.Join(problems, "; "), 500)
+	}
+
+	return res
+}
+
+// replicaStatus is down with none of want available and degraded short of
+// it.
+func replicaStatus(available, want, missing int) string {
+	switch {
+	case want > 0 && available == 0:
+		return StatusDown
+	case missing > 0:
+		return StatusDegraded
+	}
+	return StatusOperational
+}
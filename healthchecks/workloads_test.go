@@ -0,0 +1,250 @@
+package healthchecks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeKube serves the objects it holds, by namespace.
+type fakeKube struct {
+	deployments  []*Deployment
+	statefulSets []*StatefulSet
+	pods         []*Pod
+	err          error
+}
+
+func (f *fakeKube) Deployments(ctx context.Context, namespace, selector string) ([]*Deployment, error) {
+	ret := []*Deployment{}
+	for _, d := range f.deployments {
+		if d.Metadata.Namespace == namespace {
+			ret = append(ret, d)
+		}
+	}
+	return ret, f.err
+}
+
+func (f *fakeKube) StatefulSets(ctx context.Context, namespace, selector string) ([]*StatefulSet, error) {
+	ret := []*StatefulSet{}
+	for _, s := range f.statefulSets {
+		if s.Metadata.Namespace == namespace {
+			ret = append(ret, s)
+		}
+	}
+	return ret, f.err
+}
+
+func (f *fakeKube) Pods(ctx context.Context, namespace, selector string) ([]*Pod, error) {
+	ret := []*Pod{}
+	for _, p := range f.pods {
+		if p.Metadata.Namespace == namespace {
+			ret = append(ret, p)
+		}
+	}
+	return ret, f.err
+}
+
+func (f *fakeKube) Services(ctx context.Context, namespace, selector string) ([]*Service, error) {
+	return nil, f.err
+}
+
+func (f *fakeKube) Ingresses(ctx context.Context, namespace, selector string) ([]*Ingress, error) {
+	return nil, f.err
+}
+
+// kubeObject decodes an API server style JSON object into v.
+func kubeObject(t *testing.T, v interface{}, format string, args ...interface{}) {
+	t.Helper()
+	if err := json.Unmarshal([]byte(fmt.Sprintf(format, args...)), v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func deployment(t *testing.T, name string, want, available int) *Deployment {
+	d := &Deployment{}
+	kubeObject(t, d, `{"metadata": {"name": %q, "namespace": "default"}, "spec": {"replicas": %d},
+		"status": {"replicas": %d, "availableReplicas": %d, "unavailableReplicas": %d}}`,
+		name, want, want, available, want-available)
+	return d
+}
+
+func pod(t *testing.T, name, phase string, created time.Time, containers string) *Pod {
+	p := &Pod{}
+	kubeObject(t, p, `{"metadata": {"name": %q, "namespace": "default", "creationTimestamp": %q},
+		"status": {"phase": %q, "containerStatuses": [%s]}}`,
+		name, created.UTC().Format(time.RFC3339), phase, containers)
+	return p
+}
+
+func restarted(name string, at time.Time) string {
+	return fmt.Sprintf(`{"name": %q, "restartCount": 1, "lastState": {"terminated": {"reason": "OOMKilled", "exitCode": 137, "finishedAt": %q}}}`,
+		name, at.UTC().Format(time.RFC3339))
+}
+
+func newTestKubernetesCheck(t *testing.T, client KubeClient, config string) Checker {
+	t.Helper()
+	cfg := &ChecksConfig{Kubernetes: &KubeConfig{client: client}}
+	c, err := checkTypes["kubernetes"](json.RawMessage(config), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestKubernetesCheck(t *testing.T) {
+	now := time.Now()
+	crashLooping := `{"name": "api", "state": {"waiting": {"reason": "CrashLoopBackOff"}}}`
+
+	tests := []struct {
+		name       string
+		kube       func(t *testing.T) *fakeKube
+		config     string
+		status     string
+		component  string
+		metric     string
+		metricWant float64
+	}{
+		{
+			name: "healthy",
+			kube: func(t *testing.T) *fakeKube {
+				return &fakeKube{
+					deployments: []*Deployment{deployment(t, "api", 2, 2)},
+					pods:        []*Pod{pod(t, "api-1", "Running", now.Add(-time.Hour), `{"name": "api", "ready": true}`)},
+				}
+			},
+			status: StatusOperational,
+		},
+		{
+			name: "crash looping",
+			kube: func(t *testing.T) *fakeKube {
+				return &fakeKube{
+					deployments: []*Deployment{deployment(t, "api", 2, 2)},
+					pods:        []*Pod{pod(t, "api-1", "Running", now.Add(-time.Hour), crashLooping)},
+				}
+			},
+			status:     StatusDegraded,
+			component:  "pod/default/api-1",
+			metric:     "crash_looping",
+			metricWant: 1,
+		},
+		{
+			name: "stuck pending",
+			kube: func(t *testing.T) *fakeKube {
+				return &fakeKube{pods: []*Pod{pod(t, "api-1", "Pending", now.Add(-10*time.Minute), "")}}
+			},
+			status:     StatusDegraded,
+			component:  "pod/default/api-1",
+			metric:     "pending_pods",
+			metricWant: 1,
+		},
+		{
+			name: "pending within grace",
+			kube: func(t *testing.T) *fakeKube {
+				return &fakeKube{pods: []*Pod{pod(t, "api-1", "Pending", now.Add(-time.Minute), "")}}
+			},
+			status: StatusOperational,
+		},
+		{
+			name: "some replicas unavailable",
+			kube: func(t *testing.T) *fakeKube {
+				return &fakeKube{deployments: []*Deployment{deployment(t, "api", 3, 1)}}
+			},
+			status:     StatusDegraded,
+			component:  "deployment/default/api",
+			metric:     "unavailable_replicas",
+			metricWant: 2,
+		},
+		{
+			name: "no replicas available",
+			kube: func(t *testing.T) *fakeKube {
+				return &fakeKube{deployments: []*Deployment{deployment(t, "api", 2, 0)}}
+			},
+			status:     StatusDown,
+			component:  "deployment/default/api",
+			metric:     "unavailable_replicas",
+			metricWant: 2,
+		},
+		{
+			name: "restarts under threshold",
+			kube: func(t *testing.T) *fakeKube {
+				return &fakeKube{pods: []*Pod{pod(t, "api-1", "Running", now.Add(-time.Hour), restarted("api", now.Add(-time.Minute)))}}
+			},
+			config:     `{"restarts": {"warning": 2, "critical": 4}}`,
+			status:     StatusOperational,
+			metric:     "restarts",
+			metricWant: 1,
+		},
+		{
+			name: "restarts at warning",
+			kube: func(t *testing.T) *fakeKube {
+				return &fakeKube{pods: []*Pod{
+					pod(t, "api-1", "Running", now.Add(-time.Hour), restarted("api", now.Add(-time.Minute))),
+					pod(t, "api-2", "Running", now.Add(-time.Hour), restarted("api", now.Add(-2*time.Minute))),
+				}}
+			},
+			config:     `{"restarts": {"warning": 2, "critical": 4}}`,
+			status:     StatusDegraded,
+			metric:     "restarts",
+			metricWant: 2,
+		},
+		{
+			name: "restart outside window",
+			kube: func(t *testing.T) *fakeKube {
+				return &fakeKube{pods: []*Pod{pod(t, "api-1", "Running", now.Add(-time.Hour), restarted("api", now.Add(-30*time.Minute)))}}
+			},
+			status:     StatusOperational,
+			metric:     "restarts",
+			metricWant: 0,
+		},
+		{
+			name: "api error",
+			kube: func(t *testing.T) *fakeKube {
+				return &fakeKube{err: fmt.Errorf("forbidden")}
+			},
+			status: StatusUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := `{"namespaces": ["default"]}`
+			if tt.config != "" {
+				config = strings.Replace(tt.config, "{", `{"namespaces": ["default"], `, 1)
+			}
+
+			res := newTestKubernetesCheck(t, tt.kube(t), config).Check(context.Background())
+			if res.Status != tt.status {
+				t.Fatalf("status = %s, want %s: %s", res.Status, tt.status, res.Message)
+			}
+
+			if tt.component != "" {
+				found := false
+				for _, c := range res.Components {
+					if c.Name == tt.component {
+						found = true
+						if c.Status == StatusOperational {
+							t.Errorf("component %s is %s", c.Name, c.Status)
+						}
+					}
+				}
+				if !found {
+					t.Errorf("no component %s", tt.component)
+				}
+			}
+
+			if tt.metric != "" && res.Metrics[tt.metric] != tt.metricWant {
+				t.Errorf("%s = %v, want %v", tt.metric, res.Metrics[tt.metric], tt.metricWant)
+			}
+		})
+	}
+}
+
+func TestKubernetesCheckRequiresNamespaces(t *testing.T) {
+	cfg := &ChecksConfig{Kubernetes: &KubeConfig{client: &fakeKube{}}}
+	if _, err := checkTypes["kubernetes"](json.RawMessage(`{}`), cfg); err == nil {
+		t.Fatal("expected an error without namespaces")
+	}
+}
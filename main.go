@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"crypto/tls"
 	"database/sql"
 	"fmt"
 	"net/http"
@@ -32,13 +31,24 @@ type Config struct {
 	DbName     string `envconfig:"DB_NAME" required:"true"`
 	DbOpts     string `envconfig:"DB_OPTS" required:"false"`
 
+	// TLS for the internal services, certificates are verified unless
+	// INTERNAL_TLS_INSECURE_SKIP_VERIFY is set.
+	InternalCAFile             string `envconfig:"INTERNAL_TLS_CA_FILE" required:"false"`
+	InternalCertFile           string `envconfig:"INTERNAL_TLS_CERT_FILE" required:"false"`
+	InternalKeyFile            string `envconfig:"INTERNAL_TLS_KEY_FILE" required:"false"`
+	InternalInsecureSkipVerify bool   `envconfig:"INTERNAL_TLS_INSECURE_SKIP_VERIFY" default:"false"`
+
 	PlatformEndpoint       string `envconfig:"PLATFORM_ENDPOINT" required:"false"`
 	FulfillmentHealthcheck string `envconfig:"FULFILLMENT_ENDPOINT" required:"false"`
 	CrmHealthcheck         string `envconfig:"CRM_ENDPOINT" required:"false"`
 	StudyHealthcheck       string `envconfig:"STUDY_ENDPOINT" required:"false"`
 
 	GoogleProject string `envconfig:"GOOGLE_PROJECT" required:"true"`
-	ClusterName   string `envconfig:"CLUSTER_NAME" required:"true"`
+	ClusterName   string `envconfig:"CLUSTER_NAME" required:"false"`
+
+	// MetricsConfig lists the clusters making up the infra status, see
+	// metrics.LoadClusters. Without it only CLUSTER_NAME is monitored.
+	MetricsConfig string `envconfig:"METRICS_CONFIG" required:"false"`
 	BucketName    string `envconfig:"BUCKET_NAME" required:"true"`
 
 	HibbertEndpoint string `envconfig:"HIBBERT_ENDPOINT" required:"true"`
@@ -52,6 +62,30 @@ type Config struct {
 	AZCRMUrl        string `envconfig:"AZ_CRM_URL" required:"true"`
 	XAppId          string `envconfig:"X_APP_ID" required:"true"`
 
+	HibbertOwner string `envconfig:"HIBBERT_OWNER" default:"fulfillment"`
+	StripeOwner  string `envconfig:"STRIPE_OWNER" default:"platform"`
+	AZCRMOwner   string `envconfig:"AZ_CRM_OWNER" default:"crm"`
+
+	HibbertTokenTTL time.Duration `envconfig:"HIBBERT_TOKEN_TTL" default:"30m"`
+	HibbertBudget   int           `envconfig:"HIBBERT_REQUEST_BUDGET" default:"120"`
+	StripeBudget    int           `envconfig:"STRIPE_REQUEST_BUDGET" default:"120"`
+	AZCRMBudget     int           `envconfig:"AZ_CRM_REQUEST_BUDGET" default:"120"`
+
+	DeployToken  string        `envconfig:"DEPLOY_TOKEN" required:"false"`
+	DeployWindow time.Duration `envconfig:"DEPLOY_INCIDENT_WINDOW" default:"30m"`
+
+	// AdminToken is required by the /api/v1/admin routes.
+	AdminToken string `envconfig:"ADMIN_TOKEN" required:"false"`
+
+	// ChecksFile configures additional checks, see healthchecks.LoadChecks.
+	ChecksFile string `envconfig:"CHECKS_FILE" required:"false"`
+
+	// DiscoveryKinds turns on discovering checks from annotated
+	// "services" and/or "ingresses", see healthchecks.Discovery.
+	DiscoveryKinds      []string      `envconfig:"DISCOVERY_KINDS" required:"false"`
+	DiscoveryNamespaces []string      `envconfig:"DISCOVERY_NAMESPACES" required:"false"`
+	DiscoveryInterval   time.Duration `envconfig:"DISCOVERY_INTERVAL" default:"1m"`
+
 	PORT string `envconfig:"PORT"`
 }
 
@@ -89,8 +123,16 @@ func main() {
 
 	statusStore := status.New(db)
 
-	customTransport := http.DefaultTransport.(*http.Transport).Clone()
-	customTransport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	internalTLS := &healthchecks.TLSConfig{
+		CAFile:             cfg.InternalCAFile,
+		CertFile:           cfg.InternalCertFile,
+		KeyFile:            cfg.InternalKeyFile,
+		InsecureSkipVerify: cfg.InternalInsecureSkipVerify,
+	}
+	customTransport, err := healthchecks.NewTransport(internalTLS)
+	if err != nil {
+		clog.Fatalf("unable to configure internal tls: %v", err)
+	}
 	internalClient := &http.Client{
 		Transport: &ochttp.Transport{
 			// Use Google Cloud propagation format.
@@ -122,14 +164,56 @@ func main() {
 			ClientSecret:    cfg.ClientSecret,
 			AZCRMUrl:        cfg.AZCRMUrl,
 			XAppId:          cfg.XAppId,
+			HibbertOwner:    cfg.HibbertOwner,
+			StripeOwner:     cfg.StripeOwner,
+			AZCRMOwner:      cfg.AZCRMOwner,
+			HibbertTokenTTL: cfg.HibbertTokenTTL,
+			HibbertBudget:   cfg.HibbertBudget,
+			StripeBudget:    cfg.StripeBudget,
+			AZCRMBudget:     cfg.AZCRMBudget,
 		},
 	}
 
-	metricsClient, err := metrics.New(cfg.GoogleProject, cfg.ClusterName)
+	clusters := []*metrics.Cluster{}
+	switch {
+	case cfg.MetricsConfig != "":
+		clusters, err = metrics.LoadClusters(cfg.MetricsConfig)
+		if err != nil {
+			clog.Fatalf("unable to load metrics config: %v", err)
+		}
+	case cfg.ClusterName != "":
+		clusters = append(clusters, metrics.NewCluster(cfg.GoogleProject, cfg.ClusterName))
+	default:
+		clog.Fatalf("config: one of METRICS_CONFIG or CLUSTER_NAME is required")
+	}
+
+	metricsClient, err := metrics.New(clusters)
 	if err != nil {
 		clog.Fatalf("unable to create metrics client: %v", err)
 	}
 
+	checks := []*healthchecks.Check{}
+	if cfg.ChecksFile != "" {
+		checks, err = healthchecks.LoadChecks(cfg.ChecksFile)
+		if err != nil {
+			clog.Fatalf("unable to load checks: %v", err)
+		}
+	}
+
+	var discovery *healthchecks.Discovery
+	if len(cfg.DiscoveryKinds) > 0 {
+		kube, err := healthchecks.NewKubeClient(&healthchecks.KubeConfig{})
+		if err != nil {
+			clog.Fatalf("unable to create kubernetes client for discovery: %v", err)
+		}
+		discovery = &healthchecks.Discovery{
+			Client:     kube,
+			Namespaces: cfg.DiscoveryNamespaces,
+			Kinds:      cfg.DiscoveryKinds,
+			TLS:        internalTLS,
+		}
+	}
+
 	ctx := context.Background()
 	storageClient, err := storage.NewClient(ctx)
 	if err != nil {
@@ -141,12 +225,24 @@ func main() {
 		Statuses:     statusStore,
 		Metrics:      metricsClient,
 		Storage:      storageClient,
+		Checks:       checks,
+		DeployToken:  cfg.DeployToken,
+		DeployWindow: cfg.DeployWindow,
+		AdminToken:   cfg.AdminToken,
+
+		Discovery:         discovery,
+		DiscoveryInterval: cfg.DiscoveryInterval,
 	}
 	s := server.New(scfg, handler)
 
+	services := []string{"platform-api", "fulfillment-api", "crm-api",
+		"study-service-api", "infra", "hibbert-api", "stripe-api", "azcrm-api", "study-ui", "platform-ui"}
+	for _, c := range checks {
+		services = append(services, c.Name)
+	}
+
 	//init files in gcp
-	for _, service := range []string{"platform-api", "fulfillment-api", "crm-api",
-		"study-service-api", "infra", "hibbert-api", "azcrm-api", "study-ui", "platform-ui"} {
+	for _, service := range services {
 
 		filename := service + "-logs.csv"
 
@@ -166,6 +262,7 @@ func main() {
 	}
 
 	go handler.AllChecks(ctx, cfg.BucketName)
+	handler.RunChecks(ctx, cfg.BucketName)
 
 	clog.Infof("listening on %s", s.Addr)
 	fmt.Println(s.ListenAndServe())
@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"sort"
+	"time"
+)
+
+// NodeBreakdown is one node's utilization and how it fared against the
+// cluster's rules. Series are newest first, the current values are left
+// out when the node has stopped reporting.
+type NodeBreakdown struct {
+	Node          string        `json:"node"`
+	Status        string        `json:"status"`
+	Cpu           []Point       `json:"cpu"`
+	Memory        []Point       `json:"memory"`
+	CurrentCpu    *float64      `json:"current_cpu"`
+	CurrentMemory *float64      `json:"current_memory"`
+	Rules         []*RuleResult `json:"rules"`
+}
+
+// Breakdown splits the nodes' series and e's results up by node, in node
+// name order.
+func (n *Nodes) Breakdown(e *Evaluation) []*NodeBreakdown {
+	byNode := map[string]*NodeBreakdown{}
+	node := func(name string) *NodeBreakdown {
+		b, ok := byNode[name]
+		if !ok {
+			b = &NodeBreakdown{Node: name, Status: StatusOk, Cpu: []Point{}, Memory: []Point{}, Rules: []*RuleResult{}}
+			byNode[name] = b
+		}
+		return b
+	}
+
+	at := n.At
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	for _, m := range n.Cpu {
+		b := node(m.Node)
+		b.Cpu = m.Points
+		b.CurrentCpu = current(m.Points, at)
+	}
+	for _, m := range n.Memory {
+		b := node(m.Node)
+		b.Memory = m.Points
+		b.CurrentMemory = current(m.Points, at)
+	}
+	for _, r := range e.Results {
+		b := node(r.Node)
+		b.Rules = append(b.Rules, r)
+		b.Status = Worse(b.Status, r.Status)
+	}
+
+	ret := []*NodeBreakdown{}
+	for _, b := range byNode {
+		ret = append(ret, b)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Node < ret[j].Node })
+
+	return ret
+}
+
+// current is the newest value unless it's stale.
+func current(points []Point, at time.Time) *float64 {
+	points = newestFirst(points)
+	if len(points) == 0 || at.Sub(points[0].Time) > maxStaleness {
+		return nil
+	}
+	v := points[0].Value
+	return &v
+}
@@ -0,0 +1,117 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"time"
+)
+
+const (
+	// default utilization above which a cluster's nodes are unhealthy
+	defaultThreshold = 0.9
+
+	// the least history fetched, what the breakdown shows
+	defaultLookback = 30 * time.Minute
+)
+
+// Cluster is a GKE cluster whose nodes make up part of the infra status.
+type Cluster struct {
+	Project string `json:"project"`
+	Cluster string `json:"cluster"`
+
+	// CpuThreshold and MemoryThreshold are utilization from 0 to 1 that
+	// degrade the cluster when no Rules are given, see defaults.
+	CpuThreshold    float64 `json:"cpu_threshold"`
+	MemoryThreshold float64 `json:"memory_threshold"`
+
+	Rules []*Rule `json:"rules"`
+}
+
+// Name is how the cluster is reported, project/cluster.
+func (c *Cluster) Name() string {
+	return c.Project + "/" + c.Cluster
+}
+
+// LoadClusters reads the metrics config at path, a JSON document like
+//
+//	{
+//		"clusters": [
+//			{"project": "cutter-214115", "cluster": "cutter-dev-gke-cluster"},
+//			{"project": "cutter-prod", "cluster": "cutter-prod-gke-cluster", "rules": [
+//				{"metric": "cpu", "aggregation": "p95", "window": "15m", "min_duration": "5m", "warning": 0.8, "critical": 0.95}
+//			]}
+//		]
+//	}
+func LoadClusters(path string) ([]*Cluster, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &struct {
+		Clusters []*Cluster `json:"clusters"`
+	}{}
+	if err := json.Unmarshal(b, cfg); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+
+	if len(cfg.Clusters) == 0 {
+		return nil, fmt.Errorf("%s: no clusters", path)
+	}
+	for _, c := range cfg.Clusters {
+		if c.Project == "" || c.Cluster == "" {
+			return nil, fmt.Errorf("%s: clusters need a project and a cluster", path)
+		}
+		c.defaults()
+		for _, r := range c.Rules {
+			if err := r.validate(); err != nil {
+				return nil, fmt.Errorf("%s: cluster %s: %v", path, c.Name(), err)
+			}
+		}
+	}
+
+	return cfg.Clusters, nil
+}
+
+// NewCluster is a cluster with the default thresholds.
+func NewCluster(project, cluster string) *Cluster {
+	c := &Cluster{Project: project, Cluster: cluster}
+	c.defaults()
+	return c
+}
+
+// defaults fills in the thresholds and, without rules, ones close to what
+// the infra status used to check: CPU averaged over ten minutes and the
+// latest memory. The old check also needed the latest CPU sample over the
+// threshold, and only went past it rather than reaching it, so a busy
+// cluster whose CPU has just dipped now stays degraded until the average
+// comes down.
+func (c *Cluster) defaults() {
+	if c.CpuThreshold <= 0 {
+		c.CpuThreshold = defaultThreshold
+	}
+	if c.MemoryThreshold <= 0 {
+		c.MemoryThreshold = defaultThreshold
+	}
+
+	if len(c.Rules) == 0 {
+		cpu, mem := c.CpuThreshold, c.MemoryThreshold
+		c.Rules = []*Rule{
+			{Name: "cpu-avg", Metric: "cpu", Aggregation: "avg", Window: Duration{10 * time.Minute}, Warning: &cpu},
+			{Name: "memory-last", Metric: "memory", Aggregation: "last", Window: Duration{time.Minute}, Warning: &mem},
+		}
+	}
+}
+
+// lookback is how much history to fetch for the cluster's rules to see
+// their whole window at every step of their min duration.
+func (c *Cluster) lookback() time.Duration {
+	d := defaultLookback
+	for _, r := range c.Rules {
+		if need := r.Window.Duration + r.MinDuration.Duration + sampleInterval; need > d {
+			d = need
+		}
+	}
+	return d
+}
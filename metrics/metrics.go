@@ -3,6 +3,7 @@ package metrics
 import (
 	"context"
 	"fmt"
+	"math"
 	"time"
 
 	monitoring "cloud.google.com/go/monitoring/apiv3/v2"
@@ -11,11 +12,8 @@ import (
 )
 
 type Metrics struct {
-	client          *monitoring.QueryClient
-	cluster         string
-	project         string
-	nodeMemoryQuery string
-	nodeCpuQuery    string
+	client   *monitoring.QueryClient
+	Clusters []*Cluster
 }
 
 type NodeMetrics struct {
@@ -23,34 +21,27 @@ type NodeMetrics struct {
 	CpuUsage    float64
 }
 
+// Point is a sample of a node's utilization, from 0 to 1.
+type Point struct {
+	Time  time.Time `json:"time"`
+	Value float64   `json:"value"`
+}
+
+// NodeMetric is a node's samples, newest first. There may be gaps where
+// the node didn't report.
 type NodeMetric struct {
 	Node   string
-	Values []float64
+	Points []Point
 }
 
+// Nodes are the series for a cluster's nodes as of At, when they were
+// fetched.
 type Nodes struct {
+	At     time.Time
 	Memory []NodeMetric
 	Cpu    []NodeMetric
 }
 
-func (n *Nodes) Healthy() bool {
-	for _, cpu := range n.Cpu {
-		ten := avg(cpu.Values[:min(len(cpu.Values), 10)])
-		last := cpu.Values[0]
-		if ten > 0.9 && last > 0.9 {
-			return false
-		}
-	}
-
-	for _, mem := range n.Memory {
-		if mem.Values[0] > 0.9 {
-			return false
-		}
-	}
-
-	return true
-}
-
 func avg(xs []float64) float64 {
 	var sum float64
 	for _, x := range xs {
@@ -59,13 +50,6 @@ func avg(xs []float64) float64 {
 	return sum / float64(len(xs))
 }
 
-func min(a, b int) int {
-	if a < b {
-		return a
-	}
-	return b
-}
-
 func max(a, b float64) float64 {
 	if a > b {
 		return a
@@ -93,7 +77,9 @@ func IsHealthy(nm map[string]*NodeMetrics) bool {
 	return true
 }
 
-func New(project string, cluster string) (*Metrics, error) {
+// New queries Cloud Monitoring for clusters, which may be in different
+// projects.
+func New(clusters []*Cluster) (*Metrics, error) {
 	ctx := context.Background()
 	client, err := monitoring.NewQueryClient(ctx)
 	if err != nil {
@@ -101,11 +87,8 @@ func New(project string, cluster string) (*Metrics, error) {
 	}
 
 	return &Metrics{
-		client:          client,
-		cluster:         cluster,
-		project:         fmt.Sprintf("projects/%s", project),
-		nodeMemoryQuery: fmt.Sprintf(nodeMemoryQuery, cluster),
-		nodeCpuQuery:    fmt.Sprintf(nodeCpuQuery, cluster),
+		client:   client,
+		Clusters: clusters,
 	}, nil
 }
 
@@ -119,7 +102,7 @@ var nodeMemoryQuery string = `
 fetch k8s_node
 | metric 'kubernetes.io/node/memory/allocatable_utilization'
 | filter (resource.cluster_name == '%s')
-| within 30m
+| within %dm
 | group_by 1m,
     [value_allocatable_utilization_mean: mean(value.allocatable_utilization)]
 	| every 1m
@@ -132,7 +115,7 @@ var nodeCpuQuery string = `
 fetch k8s_node
 | metric 'kubernetes.io/node/cpu/allocatable_utilization'
 | filter (resource.cluster_name == '%s')
-| within 30m
+| within %dm
 | group_by 1m,
     [value_allocatable_utilization_mean: mean(value.allocatable_utilization)]
 	| every 1m
@@ -141,27 +124,32 @@ fetch k8s_node
 		       aggregate(value_allocatable_utilization_mean)]
 `
 
-func (m *Metrics) GetNodeMetrics(ctx context.Context) (*Nodes, error) {
+func (m *Metrics) GetNodeMetrics(ctx context.Context, c *Cluster) (*Nodes, error) {
+	at := time.Now()
+	project := fmt.Sprintf("projects/%s", c.Project)
+
+	within := int(math.Ceil(c.lookback().Minutes()))
 
-	nodeMem, err := m.query(ctx, m.nodeMemoryQuery)
+	nodeMem, err := m.query(ctx, project, fmt.Sprintf(nodeMemoryQuery, c.Cluster, within))
 	if err != nil {
 		return nil, err
 	}
 
-	nodeCpu, err := m.query(ctx, m.nodeCpuQuery)
+	nodeCpu, err := m.query(ctx, project, fmt.Sprintf(nodeCpuQuery, c.Cluster, within))
 	if err != nil {
 		return nil, err
 	}
 
 	return &Nodes{
+		At:     at,
 		Memory: nodeMem,
 		Cpu:    nodeCpu,
 	}, nil
 }
 
-func (m *Metrics) query(ctx context.Context, query string) ([]NodeMetric, error) {
+func (m *Metrics) query(ctx context.Context, project, query string) ([]NodeMetric, error) {
 	req := &monitoringpb.QueryTimeSeriesRequest{
-		Name:  m.project,
+		Name:  project,
 		Query: query,
 	}
 
@@ -177,20 +165,27 @@ func (m *Metrics) query(ctx context.Context, query string) ([]NodeMetric, error)
 			return nil, err
 		}
 
-		if len(resp.PointData) == 0 {
+		if len(resp.LabelValues) == 0 {
 			continue
 		}
 
 		node := resp.LabelValues[0].GetStringValue()
-		var values []float64
+		points := []Point{}
 
 		for _, p := range resp.PointData {
-			values = append(values, p.Values[0].GetDoubleValue())
+			if len(p.Values) == 0 || p.TimeInterval.GetEndTime() == nil {
+				continue
+			}
+			points = append(points, Point{
+				Time:  p.TimeInterval.GetEndTime().AsTime(),
+				Value: p.Values[0].GetDoubleValue(),
+			})
 		}
 
+		// don't rely on the API's order
 		ret = append(ret, NodeMetric{
 			Node:   node,
-			Values: values,
+			Points: newestFirst(points),
 		})
 	}
 
@@ -0,0 +1,281 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Statuses of a cluster's nodes, matching the ones checks report.
+const (
+	StatusOk       = "Ok"
+	StatusDegraded = "degraded"
+	StatusNoData   = "no data"
+	StatusUnknown  = "unknown"
+	StatusDown     = "down"
+)
+
+// severity ranks statuses for rollups. No data ranks below degraded, a
+// cluster that can't be measured mustn't hide one that's known to breach.
+var severity = map[string]int{
+	StatusOk:       0,
+	StatusNoData:   1,
+	StatusDegraded: 2,
+	StatusUnknown:  3,
+	StatusDown:     4,
+}
+
+const (
+	// queries return a sample a minute
+	sampleInterval = time.Minute
+
+	// a node whose newest sample is older than this has no data
+	maxStaleness = 5 * time.Minute
+)
+
+// Rule degrades a cluster when a node's Metric, aggregated over Window,
+// reaches Warning and takes it down at Critical. The aggregate has to stay
+// there for MinDuration before it counts.
+type Rule struct {
+	Name        string   `json:"name"`
+	Metric      string   `json:"metric"`
+	Aggregation string   `json:"aggregation"`
+	Window      Duration `json:"window"`
+	MinDuration Duration `json:"min_duration"`
+	Warning     *float64 `json:"warning"`
+	Critical    *float64 `json:"critical"`
+}
+
+// Duration reads "10m" style durations from the metrics config.
+type Duration struct {
+	time.Duration
+}
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	d.Duration = v
+
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+var aggregations = map[string]func([]float64) float64{
+	"avg":  avg,
+	"max":  maxOf,
+	"p95":  p95,
+	"last": func(xs []float64) float64 { return xs[0] },
+}
+
+func (r *Rule) validate() error {
+	if r.Aggregation == "" {
+		r.Aggregation = "avg"
+	}
+	if r.Name == "" {
+		r.Name = r.Metric + "-" + r.Aggregation
+	}
+
+	switch r.Metric {
+	case "cpu", "memory":
+	default:
+		return fmt.Errorf("rule %s: metric must be cpu or memory", r.Name)
+	}
+	if _, ok := aggregations[r.Aggregation]; !ok {
+		return fmt.Errorf("rule %s: aggregation must be avg, max, p95 or last", r.Name)
+	}
+	if r.Window.Duration < sampleInterval {
+		r.Window.Duration = sampleInterval
+	}
+	if r.Warning == nil && r.Critical == nil {
+		return fmt.Errorf("rule %s: one of warning or critical is required", r.Name)
+	}
+	return nil
+}
+
+// RuleResult is how a node fared against a rule.
+type RuleResult struct {
+	Node   string  `json:"node"`
+	Rule   string  `json:"rule"`
+	Metric string  `json:"metric"`
+	Value  float64 `json:"value"`
+	Limit  float64 `json:"limit"`
+	Status string  `json:"status"`
+}
+
+func (r *RuleResult) String() string {
+	return fmt.Sprintf("%s %s %.2f >= %.2f", r.Node, r.Rule, r.Value, r.Limit)
+}
+
+// Evaluation is how a cluster's nodes fared against its rules, Breaches
+// being the Results that are degraded or down. A node that stopped
+// reporting, perhaps scaled away, has no data without affecting Status,
+// which is no data only when none of the nodes have any.
+type Evaluation struct {
+	Status   string        `json:"status"`
+	Results  []*RuleResult `json:"results"`
+	Breaches []*RuleResult `json:"breaches"`
+}
+
+// Evaluate checks every node against every rule.
+func (c *Cluster) Evaluate(n *Nodes) *Evaluation {
+	e := &Evaluation{Status: StatusOk, Results: []*RuleResult{}, Breaches: []*RuleResult{}}
+
+	at := n.At
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	reporting := false
+	for _, r := range c.Rules {
+		series := n.Cpu
+		if r.Metric == "memory" {
+			series = n.Memory
+		}
+
+		for _, node := range series {
+			res := r.evaluate(node, at)
+			e.Results = append(e.Results, res)
+
+			switch res.Status {
+			case StatusNoData:
+			case StatusOk:
+				reporting = true
+			default:
+				reporting = true
+				e.Breaches = append(e.Breaches, res)
+				e.Status = Worse(e.Status, res.Status)
+			}
+		}
+	}
+
+	if !reporting {
+		e.Status = StatusNoData
+	}
+
+	return e
+}
+
+// evaluate aggregates the Window ending at each minute of the last
+// MinDuration, counting back from the newest sample, and is as bad as the
+// best of those. Minutes whose window falls in a gap are skipped, but
+// there must be samples going back MinDuration for a breach to count.
+// Limit is the threshold breached, or the lowest one when Ok.
+func (r *Rule) evaluate(node NodeMetric, at time.Time) *RuleResult {
+	res := &RuleResult{Node: node.Node, Rule: r.Name, Metric: r.Metric, Status: StatusDown}
+
+	limit := r.Warning
+	if limit == nil {
+		limit = r.Critical
+	}
+	res.Limit = *limit
+
+	points := newestFirst(node.Points)
+	if len(points) == 0 || at.Sub(points[0].Time) > maxStaleness {
+		res.Status = StatusNoData
+		return res
+	}
+
+	steps := int(r.MinDuration.Duration / sampleInterval)
+	if steps < 1 {
+		steps = 1
+	}
+	aggregate := aggregations[r.Aggregation]
+	oldest := points[len(points)-1].Time
+
+	for k := 0; k < steps && res.Status != StatusOk; k++ {
+		end := points[0].Time.Add(-time.Duration(k) * sampleInterval)
+		if end.Before(oldest) {
+			// not enough history to have breached for MinDuration
+			res.Status = StatusOk
+			break
+		}
+
+		values := window(points, end.Add(-r.Window.Duration), end)
+		if len(values) == 0 {
+			continue
+		}
+
+		v := aggregate(values)
+		if k == 0 {
+			res.Value = v
+		}
+
+		if s := r.level(v); severity[s] < severity[res.Status] {
+			res.Status = s
+		}
+	}
+
+	if res.Status == StatusDown && r.Critical != nil {
+		res.Limit = *r.Critical
+	}
+
+	return res
+}
+
+// newestFirst is points sorted newest first, copied if they weren't.
+func newestFirst(points []Point) []Point {
+	newer := func(i, j int) bool { return points[i].Time.After(points[j].Time) }
+	if sort.SliceIsSorted(points, newer) {
+		return points
+	}
+
+	sorted := append([]Point{}, points...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Time.After(sorted[j].Time) })
+	return sorted
+}
+
+// window is the values of points, newest first, in (from, to].
+func window(points []Point, from, to time.Time) []float64 {
+	values := []float64{}
+	for _, p := range points {
+		if p.Time.After(from) && !p.Time.After(to) {
+			values = append(values, p.Value)
+		}
+	}
+	return values
+}
+
+func (r *Rule) level(v float64) string {
+	switch {
+	case r.Critical != nil && v >= *r.Critical:
+		return StatusDown
+	case r.Warning != nil && v >= *r.Warning:
+		return StatusDegraded
+	}
+	return StatusOk
+}
+
+// Worse is whichever of two statuses is more severe.
+func Worse(a, b string) string {
+	if severity[b] > severity[a] {
+		return b
+	}
+	return a
+}
+
+func maxOf(xs []float64) float64 {
+	m := xs[0]
+	for _, x := range xs[1:] {
+		m = max(m, x)
+	}
+	return m
+}
+
+func p95(xs []float64) float64 {
+	sorted := append([]float64{}, xs...)
+	sort.Float64s(sorted)
+	i := int(math.Ceil(0.95*float64(len(sorted)))) - 1
+	return sorted[i]
+}
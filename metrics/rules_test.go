@@ -0,0 +1,324 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+)
+
+var testAt = time.Date(2021, 4, 1, 12, 0, 0, 0, time.UTC)
+
+// minutes is a sample per minute ago listed, newest first, all of value v.
+func minutes(v float64, ago ...int) []Point {
+	points := []Point{}
+	for _, m := range ago {
+		points = append(points, Point{Time: testAt.Add(-time.Duration(m) * time.Minute), Value: v})
+	}
+	return points
+}
+
+// span is ago minutes from..to inclusive, newest first.
+func span(from, to int) []int {
+	ret := []int{}
+	for m := from; m <= to; m++ {
+		ret = append(ret, m)
+	}
+	return ret
+}
+
+func testRule(t *testing.T, aggregation string, window, minDuration time.Duration) *Rule {
+	t.Helper()
+	warning, critical := 0.8, 0.95
+	r := &Rule{
+		Metric:      "cpu",
+		Aggregation: aggregation,
+		Window:      Duration{window},
+		MinDuration: Duration{minDuration},
+		Warning:     &warning,
+		Critical:    &critical,
+	}
+	if err := r.validate(); err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestRuleEvaluate(t *testing.T) {
+	tests := []struct {
+		name        string
+		aggregation string
+		window      time.Duration
+		minDuration time.Duration
+		points      []Point
+		status      string
+		value       float64
+		limit       float64
+	}{
+		{
+			name:   "empty series",
+			points: []Point{},
+			status: StatusNoData,
+			limit:  0.8,
+		},
+		{
+			name:   "stale newest point",
+			points: minutes(0.99, span(6, 30)...),
+			status: StatusNoData,
+			limit:  0.8,
+		},
+		{
+			name:   "newest point just in time",
+			points: minutes(0.5, span(5, 30)...),
+			status: StatusOk,
+			value:  0.5,
+			limit:  0.8,
+		},
+		{
+			name:   "below warning",
+			points: minutes(0.5, span(0, 30)...),
+			status: StatusOk,
+			value:  0.5,
+			limit:  0.8,
+		},
+		{
+			name:   "warning",
+			points: minutes(0.9, span(0, 30)...),
+			status: StatusDegraded,
+			value:  0.9,
+			limit:  0.8,
+		},
+		{
+			name:   "critical",
+			points: minutes(0.99, span(0, 30)...),
+			status: StatusDown,
+			value:  0.99,
+			limit:  0.95,
+		},
+		{
+			// missing samples don't count as zeros and drag the average down
+			name:   "gap in the middle of the window",
+			window: 10 * time.Minute,
+			points: append(minutes(0.9, span(0, 2)...), minutes(0.9, span(7, 30)...)...),
+			status: StatusDegraded,
+			value:  0.9,
+			limit:  0.8,
+		},
+		{
+			name:   "gap in the middle of the window averages what's there",
+			window: 10 * time.Minute,
+			points: append(minutes(1, span(0, 1)...), minutes(0.6, span(8, 30)...)...),
+			status: StatusDegraded,
+			value:  (1 + 1 + 0.6 + 0.6) / 4,
+			limit:  0.8,
+		},
+		{
+			name:        "gap within min duration is skipped",
+			minDuration: 5 * time.Minute,
+			points:      append(minutes(0.9, 0), minutes(0.9, span(4, 30)...)...),
+			status:      StatusDegraded,
+			value:       0.9,
+			limit:       0.8,
+		},
+		{
+			name:        "breached for less than min duration",
+			minDuration: 5 * time.Minute,
+			points:      append(minutes(0.9, span(0, 2)...), minutes(0.5, span(3, 30)...)...),
+			status:      StatusOk,
+			value:       0.9,
+			limit:       0.8,
+		},
+		{
+			name:        "not enough history for min duration",
+			minDuration: 10 * time.Minute,
+			points:      minutes(0.99, span(0, 3)...),
+			status:      StatusOk,
+			value:       0.99,
+			limit:       0.8,
+		},
+		{
+			name:        "unsorted input",
+			aggregation: "last",
+			points:      append(append(minutes(0.5, span(1, 10)...), minutes(0.99, 0)...), minutes(0.5, span(11, 30)...)...),
+			status:      StatusDown,
+			value:       0.99,
+			limit:       0.95,
+		},
+		{
+			name:   "unsorted stale input",
+			points: append(minutes(0.99, 20), minutes(0.99, 10)...),
+			status: StatusNoData,
+			limit:  0.8,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testRule(t, tt.aggregation, tt.window, tt.minDuration)
+
+			res := r.evaluate(NodeMetric{Node: "node-1", Points: tt.points}, testAt)
+			if res.Status != tt.status {
+				t.Errorf("status = %s, want %s", res.Status, tt.status)
+			}
+			if res.Value != tt.value {
+				t.Errorf("value = %v, want %v", res.Value, tt.value)
+			}
+			if res.Limit != tt.limit {
+				t.Errorf("limit = %v, want %v", res.Limit, tt.limit)
+			}
+		})
+	}
+}
+
+func TestWindow(t *testing.T) {
+	points := append(minutes(1, span(0, 2)...), minutes(2, span(5, 6)...)...)
+
+	tests := []struct {
+		name     string
+		from, to int
+		want     []float64
+	}{
+		{"excludes from, includes to", 2, 0, []float64{1, 1}},
+		{"spans the gap", 6, 1, []float64{1, 1, 2}},
+		{"inside the gap", 4, 3, []float64{}},
+		{"before the oldest", 30, 10, []float64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from := testAt.Add(-time.Duration(tt.from) * time.Minute)
+			to := testAt.Add(-time.Duration(tt.to) * time.Minute)
+
+			got := window(points, from, to)
+			if len(got) != len(tt.want) {
+				t.Fatalf("window = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("window = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestCurrent(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []Point
+		want   *float64
+	}{
+		{"empty series", []Point{}, nil},
+		{"stale newest point", minutes(0.5, span(6, 10)...), nil},
+		{"newest", append(minutes(0.7, 0), minutes(0.5, span(1, 10)...)...), floatPtr(0.7)},
+		{"unsorted input", append(minutes(0.5, span(1, 10)...), minutes(0.7, 0)...), floatPtr(0.7)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := current(tt.points, testAt)
+			switch {
+			case got == nil && tt.want == nil:
+			case got == nil || tt.want == nil || *got != *tt.want:
+				t.Errorf("current = %v, want %v", deref(got), deref(tt.want))
+			}
+		})
+	}
+}
+
+func TestClusterEvaluate(t *testing.T) {
+	c := &Cluster{Rules: []*Rule{testRule(t, "avg", 0, 0)}}
+
+	tests := []struct {
+		name     string
+		cpu      []NodeMetric
+		status   string
+		breaches int
+	}{
+		{
+			name:   "no nodes",
+			cpu:    []NodeMetric{},
+			status: StatusNoData,
+		},
+		{
+			name: "no node reporting",
+			cpu: []NodeMetric{
+				{Node: "node-1", Points: []Point{}},
+				{Node: "node-2", Points: minutes(0.99, span(10, 30)...)},
+			},
+			status: StatusNoData,
+		},
+		{
+			name: "node stopped reporting",
+			cpu: []NodeMetric{
+				{Node: "node-1", Points: minutes(0.5, span(0, 30)...)},
+				{Node: "node-2", Points: minutes(0.99, span(10, 30)...)},
+			},
+			status: StatusOk,
+		},
+		{
+			name: "node breaching",
+			cpu: []NodeMetric{
+				{Node: "node-1", Points: minutes(0.5, span(0, 30)...)},
+				{Node: "node-2", Points: minutes(0.9, span(0, 30)...)},
+				{Node: "node-3", Points: []Point{}},
+			},
+			status:   StatusDegraded,
+			breaches: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := c.Evaluate(&Nodes{At: testAt, Cpu: tt.cpu})
+			if e.Status != tt.status {
+				t.Errorf("status = %s, want %s", e.Status, tt.status)
+			}
+			if len(e.Breaches) != tt.breaches {
+				t.Errorf("%d breaches, want %d", len(e.Breaches), tt.breaches)
+			}
+		})
+	}
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func deref(v *float64) interface{} {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
+
+func TestClusterLookback(t *testing.T) {
+	if got := NewCluster("p", "c").lookback(); got != defaultLookback {
+		t.Errorf("default lookback = %s, want %s", got, defaultLookback)
+	}
+
+	c := &Cluster{Rules: []*Rule{
+		testRule(t, "avg", 10*time.Minute, 0),
+		testRule(t, "p95", time.Hour, 15*time.Minute),
+	}}
+	if got, want := c.lookback(), 76*time.Minute; got != want {
+		t.Errorf("lookback = %s, want %s", got, want)
+	}
+}
+
+func TestWorse(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want string
+	}{
+		{StatusOk, StatusNoData, StatusNoData},
+		{StatusNoData, StatusDegraded, StatusDegraded},
+		{StatusDegraded, StatusNoData, StatusDegraded},
+		{StatusNoData, StatusUnknown, StatusUnknown},
+		{StatusDown, StatusDegraded, StatusDown},
+	}
+
+	for _, tt := range tests {
+		if got := Worse(tt.a, tt.b); got != tt.want {
+			t.Errorf("Worse(%s, %s) = %s, want %s", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
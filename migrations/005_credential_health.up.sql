@@ -0,0 +1,7 @@
+CREATE TABLE credential_health (
+    integration text PRIMARY KEY,
+    owner text,
+    status text,
+    detail text,
+    checked_at timestamptz
+);
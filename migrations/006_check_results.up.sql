@@ -0,0 +1,13 @@
+CREATE UNIQUE INDEX statuses_service_idx ON statuses (service);
+
+CREATE TABLE check_results (
+    result_id SERIAL PRIMARY KEY,
+    service text,
+    status text,
+    message text,
+    latency_ms bigint,
+    metrics jsonb,
+    checked_at timestamptz
+);
+
+CREATE INDEX check_results_service_idx ON check_results (service, checked_at DESC);
@@ -0,0 +1,8 @@
+CREATE TABLE components (
+    service text,
+    component text,
+    status text,
+    output text,
+    updated_at timestamptz,
+    PRIMARY KEY (service, component)
+);
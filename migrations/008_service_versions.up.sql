@@ -0,0 +1,10 @@
+CREATE TABLE service_versions (
+    version_id SERIAL PRIMARY KEY,
+    service text,
+    version text,
+    commit text,
+    build text,
+    first_seen timestamptz
+);
+
+CREATE INDEX service_versions_service_idx ON service_versions (service, first_seen DESC);
@@ -0,0 +1,11 @@
+CREATE TABLE deployments (
+    deployment_id SERIAL PRIMARY KEY,
+    service text,
+    version text,
+    commit text,
+    environment text,
+    deployed_by text,
+    deployed_at timestamptz
+);
+
+CREATE INDEX deployments_service_idx ON deployments (service, deployed_at DESC);
@@ -0,0 +1,2 @@
+ALTER TABLE statuses ADD COLUMN display_name text;
+ALTER TABLE statuses ADD COLUMN service_group text;
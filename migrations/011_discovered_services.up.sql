@@ -0,0 +1 @@
+ALTER TABLE statuses ADD COLUMN discovered boolean NOT NULL DEFAULT false;
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/IdeaEvolver/cutter-pkg/clog"
+	"github.com/IdeaEvolver/cutter-status-dashboard/healthchecks"
+	"github.com/IdeaEvolver/cutter-status-dashboard/status"
+)
+
+// RunChecks schedules the checks configured in the checks file, and those
+// found by Discovery when it's set. Their results are published like the
+// built in services in AllChecks.
+func (h *Handler) RunChecks(ctx context.Context, bucket string) {
+	h.scheduler = &healthchecks.Scheduler{
+		Record: func(ctx context.Context, c *healthchecks.Check, res *healthchecks.Result) {
+			h.recordCheck(ctx, bucket, c, res)
+		},
+	}
+
+	for _, c := range h.Checks {
+		h.schedule(ctx, c)
+	}
+
+	if h.Discovery != nil {
+		h.discovered = h.previouslyDiscovered(ctx)
+		go h.discover(ctx)
+	}
+}
+
+func (h *Handler) schedule(ctx context.Context, c *healthchecks.Check) {
+	if c.DisplayName != "" || c.Group != "" {
+		if err := h.Statuses.UpdateServiceInfo(ctx, c.Name, c.DisplayName, c.Group); err != nil {
+			clog.Errorf("unable to update service info for check %s: %v", c.Name, err)
+		}
+	}
+
+	h.scheduler.Add(ctx, c)
+}
+
+func (h *Handler) recordCheck(ctx context.Context, bucket string, c *healthchecks.Check, res *healthchecks.Result) {
+	r := &status.CheckResult{
+		Service:   c.Name,
+		Status:    res.Status,
+		Message:   res.Message,
+		Latency:   res.Latency,
+		Metrics:   res.Metrics,
+		CheckedAt: res.CheckedAt,
+	}
+	if err := h.Statuses.InsertCheckResult(ctx, r); err != nil {
+		clog.Errorf("unable to insert result for check %s: %v", c.Name, err)
+	}
+
+	if err := h.Statuses.UpdateStatus(ctx, c.Name, res.Status); err != nil {
+		clog.Errorf("unable to update status for check %s: %v", c.Name, err)
+	}
+
+	h.updateComponents(ctx, c.Name, res.Components)
+	h.recordVersion(ctx, c.Name, res.Version)
+
+	switch {
+	case isDown(res.Status):
+		clog.Errorw("check failed", "check", c.Name, "status", res.Status, "message", res.Message)
+		if err := h.logDown(ctx, bucket, &StatusLog{Service: c.Name, Status: res.Status}); err != nil {
+			clog.Errorf("unable to log down status for check %s: %v", c.Name, err)
+		}
+	case isImpaired(res.Status):
+		clog.Infow("check impaired", "check", c.Name, "status", res.Status, "message", res.Message)
+	}
+}
+
+// updateComponents records the components a service's health response
+// broke its status down into.
+func (h *Handler) updateComponents(ctx context.Context, service string, components []*healthchecks.Component) {
+	now := time.Now().UTC()
+
+	rows := []*status.Component{}
+	for _, c := range components {
+		rows = append(rows, &status.Component{
+			Component: c.Name,
+			Status:    c.Status,
+			Output:    c.Output,
+			UpdatedAt: now,
+		})
+	}
+
+	if err := h.Statuses.UpdateComponents(ctx, service, rows); err != nil {
+		clog.Errorf("unable to update components for %s: %v", service, err)
+	}
+}
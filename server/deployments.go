@@ -0,0 +1,81 @@
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/IdeaEvolver/cutter-status-dashboard/status"
+)
+
+type DeploymentRequest struct {
+	Service     string    `json:"service"`
+	Version     string    `json:"version"`
+	Commit      string    `json:"commit"`
+	Environment string    `json:"environment"`
+	DeployedBy  string    `json:"deployed_by"`
+	DeployedAt  time.Time `json:"deployed_at"`
+}
+
+// CreateDeployment records a deployment reported by a CI/CD pipeline.
+// Services can be named as in the status list or the outage log.
+func (h *Handler) CreateDeployment(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	req := &DeploymentRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		return badRequest(w, "invalid json: "+err.Error())
+	}
+	if req.Service == "" {
+		return badRequest(w, "service is required")
+	}
+	if req.Version == "" && req.Commit == "" {
+		return badRequest(w, "version or commit is required")
+	}
+	if req.DeployedAt.IsZero() {
+		req.DeployedAt = time.Now()
+	}
+
+	d := &status.Deployment{
+		Service:     serviceName(req.Service),
+		Version:     req.Version,
+		Commit:      req.Commit,
+		Environment: req.Environment,
+		DeployedBy:  req.DeployedBy,
+		DeployedAt:  req.DeployedAt.UTC(),
+	}
+	if err := h.Statuses.InsertDeployment(r.Context(), d); err != nil {
+		return nil, err
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	return d, nil
+}
+
+// serviceName maps an outage log name back to the service's status name.
+func serviceName(name string) string {
+	for service, log := range logNames {
+		if log == name {
+			return service
+		}
+	}
+	return name
+}
+
+func badRequest(w http.ResponseWriter, msg string) (interface{}, error) {
+	w.WriteHeader(http.StatusBadRequest)
+	return map[string]string{"error": msg}, nil
+}
+
+// requireToken only lets through requests bearing token, and none at all
+// when token isn't configured.
+func requireToken(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if token == "" || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
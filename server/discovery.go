@@ -0,0 +1,132 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/IdeaEvolver/cutter-pkg/clog"
+	"github.com/IdeaEvolver/cutter-status-dashboard/healthchecks"
+)
+
+// discoveredCheck is a scheduled discovered check, or with no check one
+// discovered before the dashboard last started.
+type discoveredCheck struct {
+	check *healthchecks.Check
+	key   string
+}
+
+// previouslyDiscovered is the services marked discovered in the store. The
+// first sync reschedules those still annotated and removes the rest, which
+// lost their annotation while the dashboard wasn't running.
+func (h *Handler) previouslyDiscovered(ctx context.Context) map[string]*discoveredCheck {
+	ret := map[string]*discoveredCheck{}
+
+	services, err := h.Statuses.GetDiscoveredServices(ctx)
+	if err != nil {
+		clog.Errorf("unable to get previously discovered services: %v", err)
+		return ret
+	}
+	for _, service := range services {
+		ret[service] = &discoveredCheck{}
+	}
+
+	return ret
+}
+
+// discover keeps the scheduled checks in line with the annotated Services
+// and Ingresses, adding checks as annotations appear and removing them,
+// and their row on the dashboard, when they go.
+func (h *Handler) discover(ctx context.Context) {
+	interval := h.DiscoveryInterval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		h.syncDiscovered(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *Handler) syncDiscovered(ctx context.Context) {
+	checks, problems, err := h.Discovery.Discover(ctx)
+	if err != nil {
+		// keep what's running rather than drop everything on an API error
+		clog.Errorf("unable to discover checks: %v", err)
+		return
+	}
+	for _, err := range problems {
+		clog.Errorf("skipping discovered check: %v", err)
+	}
+
+	reserved := map[string]bool{}
+	for service, log := range logNames {
+		reserved[service] = true
+		reserved[log] = true
+	}
+	for _, c := range h.Checks {
+		reserved[c.Name] = true
+	}
+
+	seen := map[string]bool{}
+	for _, c := range checks {
+		if reserved[c.Name] {
+			clog.Errorf("discovered check %s clashes with a configured one, ignoring it", c.Name)
+			continue
+		}
+		seen[c.Name] = true
+
+		key := discoveryKey(c)
+		if d, ok := h.discovered[c.Name]; ok && d.key == key {
+			continue
+		}
+
+		if err := h.Statuses.MarkDiscovered(ctx, c.Name); err != nil {
+			// try again next time rather than lose track of it
+			clog.Errorf("unable to mark %s discovered: %v", c.Name, err)
+			continue
+		}
+
+		clog.Infof("discovered check %s", c.Name)
+		h.discovered[c.Name] = &discoveredCheck{check: c, key: key}
+		h.schedule(ctx, c)
+	}
+
+	for name := range h.discovered {
+		if seen[name] {
+			continue
+		}
+		if reserved[name] {
+			// now configured, the row is the configured check's
+			delete(h.discovered, name)
+			continue
+		}
+
+		// stopped first, a run in progress would record it again
+		clog.Infof("removing check %s, its annotation is gone", name)
+		h.scheduler.Remove(name)
+		if err := h.Statuses.RemoveService(ctx, name); err != nil {
+			// keep it to try again next time
+			clog.Errorf("unable to remove service %s: %v", name, err)
+			continue
+		}
+		delete(h.discovered, name)
+	}
+}
+
+// discoveryKey changes when a discovered check needs rescheduling.
+func discoveryKey(c *healthchecks.Check) string {
+	url := ""
+	if http, ok := c.Checker.(*healthchecks.HTTPCheck); ok {
+		url = http.URL
+	}
+	return url + "|" + c.Interval.String() + "|" + c.DisplayName + "|" + c.Group
+}
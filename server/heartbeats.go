@@ -0,0 +1,89 @@
+package server
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/IdeaEvolver/cutter-pkg/clog"
+	"github.com/IdeaEvolver/cutter-status-dashboard/healthchecks"
+	"github.com/go-chi/chi"
+)
+
+// job output sent with a ping is kept up to this size
+const maxPingBody = 10 << 10
+
+type heartbeat struct {
+	check   *healthchecks.Check
+	checker *healthchecks.HeartbeatCheck
+}
+
+func (h *Handler) heartbeatsByToken() map[string]*heartbeat {
+	ret := map[string]*heartbeat{}
+	for _, c := range h.Checks {
+		hb, ok := c.Checker.(*healthchecks.HeartbeatCheck)
+		if !ok {
+			continue
+		}
+		if other, ok := ret[hb.Token]; ok {
+			clog.Errorf("heartbeat %s shares its token with %s, ignoring it", c.Name, other.check.Name)
+			continue
+		}
+		ret[hb.Token] = &heartbeat{check: c, checker: hb}
+	}
+	return ret
+}
+
+// Heartbeat takes pings from jobs at /heartbeats/{token}, /start when a run
+// begins and /fail when it fails. Anything sent in the body is kept as the
+// run's output.
+func (h *Handler) Heartbeat(kind string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		hb, ok := h.heartbeats[chi.URLParam(r, "token")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		body, _ := ioutil.ReadAll(io.LimitReader(r.Body, maxPingBody))
+
+		switch kind {
+		case "start":
+			hb.checker.Start()
+		case "fail":
+			hb.checker.Fail(string(body))
+		default:
+			hb.checker.Success(string(body))
+		}
+
+		// publish straight away rather than at the next interval
+		if kind != "start" && h.scheduler != nil {
+			h.scheduler.Record(r.Context(), hb.check, hb.check.Run(r.Context()))
+		}
+
+		w.Write([]byte("OK"))
+	}
+}
+
+type HeartbeatInfo struct {
+	Service  string     `json:"service"`
+	LastPing *time.Time `json:"last_ping"`
+	Status   string     `json:"status"`
+}
+
+// GetHeartbeats lists each heartbeat monitor with when it was last pinged
+// and its status. Tokens are left out, anyone holding one can ping.
+func (h *Handler) GetHeartbeats(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	ret := []*HeartbeatInfo{}
+	for _, hb := range h.heartbeats {
+		info := &HeartbeatInfo{Service: hb.check.Name, Status: hb.checker.Check(r.Context()).Status}
+		if last := hb.checker.LastPing(); !last.IsZero() {
+			info.LastPing = &last
+		}
+		ret = append(ret, info)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Service < ret[j].Service })
+	return ret, nil
+}
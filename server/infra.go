@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/IdeaEvolver/cutter-pkg/clog"
+	"github.com/IdeaEvolver/cutter-status-dashboard/healthchecks"
+	"github.com/IdeaEvolver/cutter-status-dashboard/metrics"
+)
+
+// ClusterBreakdown is the last evaluation of a cluster's nodes.
+type ClusterBreakdown struct {
+	Cluster   string                   `json:"cluster"`
+	Status    string                   `json:"status"`
+	Error     string                   `json:"error,omitempty"`
+	CheckedAt time.Time                `json:"checked_at"`
+	Nodes     []*metrics.NodeBreakdown `json:"nodes"`
+}
+
+// infraStatus evaluates each cluster's nodes against its rules, recording
+// a component per cluster under infrastructure that lists the nodes
+// breaching which rule, and rolls them up into the worst of them.
+func (h *Handler) infraStatus(ctx context.Context) string {
+	components := []*healthchecks.Component{}
+	clusters := []*ClusterBreakdown{}
+	rollup := metrics.StatusOk
+
+	for _, c := range h.Metrics.Clusters {
+		component := &healthchecks.Component{Name: c.Name()}
+		cluster := &ClusterBreakdown{Cluster: c.Name(), CheckedAt: time.Now().UTC(), Nodes: []*metrics.NodeBreakdown{}}
+
+		nodes, err := h.Metrics.GetNodeMetrics(ctx, c)
+		if err != nil {
+			clog.Errorf("unable to retrieve node metrics for %s: %v", c.Name(), err)
+			component.Status = metrics.StatusUnknown
+			component.Output = err.Error()
+			cluster.Error = err.Error()
+		} else {
+			eval := c.Evaluate(nodes)
+			component.Status = eval.Status
+			cluster.Nodes = nodes.Breakdown(eval)
+
+			breaches := []string{}
+			for _, b := range eval.Breaches {
+				breaches = append(breaches, b.String())
+			}
+			component.Output = strings.Join(breaches, "; ")
+			if eval.Status == metrics.StatusNoData {
+				component.Output = "no recent samples from any node"
+			}
+		}
+		cluster.Status = component.Status
+
+		rollup = metrics.Worse(rollup, component.Status)
+		components = append(components, component)
+		clusters = append(clusters, cluster)
+	}
+
+	h.updateComponents(ctx, "infrastructure", components)
+
+	h.infraMu.Lock()
+	h.infra = clusters
+	h.infraMu.Unlock()
+
+	return rollup
+}
+
+// GetInfrastructure is the per node breakdown behind the infra status,
+// for every cluster or just ?cluster=project/cluster.
+func (h *Handler) GetInfrastructure(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	h.infraMu.Lock()
+	defer h.infraMu.Unlock()
+
+	want := r.URL.Query().Get("cluster")
+	ret := []*ClusterBreakdown{}
+	for _, c := range h.infra {
+		if want == "" || c.Cluster == want {
+			ret = append(ret, c)
+		}
+	}
+
+	return ret, nil
+}
@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"net/http"
+	"sync"
 	"time"
 
 	"cloud.google.com/go/storage"
@@ -22,6 +23,19 @@ type StatusStore interface {
 	GetStatus(ctx context.Context, service string) (*status.Status, error)
 	UpdateServiceDown(ctx context.Context, service, status string, timestamp time.Time) error
 	GetServiceDown(ctx context.Context, service string) ([]*status.StatusReport, error)
+	UpdateCredentialHealth(ctx context.Context, c *status.CredentialHealth) error
+	GetCredentialHealth(ctx context.Context) ([]*status.CredentialHealth, error)
+	InsertCheckResult(ctx context.Context, r *status.CheckResult) error
+	UpdateComponents(ctx context.Context, service string, components []*status.Component) error
+	UpdateServiceInfo(ctx context.Context, service, displayName, group string) error
+	RemoveService(ctx context.Context, service string) error
+	MarkDiscovered(ctx context.Context, service string) error
+	GetDiscoveredServices(ctx context.Context) ([]string, error)
+	RecordVersion(ctx context.Context, v *status.ServiceVersion) error
+	GetCurrentVersions(ctx context.Context) ([]*status.ServiceVersion, error)
+	GetVersionHistory(ctx context.Context, service string) ([]*status.ServiceVersion, error)
+	InsertDeployment(ctx context.Context, d *status.Deployment) error
+	GetDeployments(ctx context.Context, service string) ([]*status.Deployment, error)
 }
 
 type Handler struct {
@@ -29,9 +43,33 @@ type Handler struct {
 	Healthchecks *healthchecks.Client
 	Metrics      *metrics.Metrics
 	Storage      *storage.Client
+	Checks       []*healthchecks.Check
+
+	// DeployToken authenticates pipelines reporting deployments, incidents
+	// starting within DeployWindow of one are flagged on the timeline.
+	DeployToken  string
+	DeployWindow time.Duration
+
+	// AdminToken authenticates the /admin routes, which are closed when it
+	// isn't set.
+	AdminToken string
+
+	// Discovery finds more checks every DiscoveryInterval, optional.
+	Discovery         *healthchecks.Discovery
+	DiscoveryInterval time.Duration
+
+	scheduler  *healthchecks.Scheduler
+	heartbeats map[string]*heartbeat
+	discovered map[string]*discoveredCheck
+
+	// the last infra evaluation, see infraStatus
+	infraMu sync.Mutex
+	infra   []*ClusterBreakdown
 }
 
 func New(cfg *service.Config, handler *Handler) *service.Server {
+	handler.heartbeats = handler.heartbeatsByToken()
+
 	router := chi.NewRouter()
 
 	router.Use(cors.New(cors.Options{
@@ -52,6 +90,18 @@ func New(cfg *service.Config, handler *Handler) *service.Server {
 		router.Route("/", func(router chi.Router) {
 			router.Method("GET", "/get-all-statuses", service.JsonHandler(handler.GetAllStatuses))
 			router.Method("GET", "/get-status", service.JsonHandler(handler.GetStatus))
+			router.Method("GET", "/versions", service.JsonHandler(handler.GetVersions))
+			router.Method("GET", "/timeline", service.JsonHandler(handler.GetTimeline))
+			router.Method("GET", "/infrastructure", service.JsonHandler(handler.GetInfrastructure))
+			router.Method("POST", "/deployments", requireToken(handler.DeployToken, service.JsonHandler(handler.CreateDeployment)))
+			router.HandleFunc("/heartbeats/{token}", handler.Heartbeat("success"))
+			router.HandleFunc("/heartbeats/{token}/start", handler.Heartbeat("start"))
+			router.HandleFunc("/heartbeats/{token}/fail", handler.Heartbeat("fail"))
+		})
+		router.Route("/admin", func(router chi.Router) {
+			router.Use(func(next http.Handler) http.Handler { return requireToken(handler.AdminToken, next) })
+			router.Method("GET", "/credentials", service.JsonHandler(handler.GetCredentialHealth))
+			router.Method("GET", "/heartbeats", service.JsonHandler(handler.GetHeartbeats))
 		})
 	})
 
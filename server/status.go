@@ -2,12 +2,14 @@ package server
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/IdeaEvolver/cutter-pkg/clog"
+	"github.com/IdeaEvolver/cutter-status-dashboard/healthchecks"
+	"github.com/IdeaEvolver/cutter-status-dashboard/metrics"
+	"github.com/IdeaEvolver/cutter-status-dashboard/status"
 	"github.com/gocarina/gocsv"
 )
 
@@ -31,6 +33,35 @@ func (h *Handler) GetAllStatuses(w http.ResponseWriter, r *http.Request) (interf
 	return h.Statuses.GetAllStatuses(r.Context())
 }
 
+func (h *Handler) GetCredentialHealth(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	return h.Statuses.GetCredentialHealth(r.Context())
+}
+
+// publishVendor records the credential health of a vendor probe and returns
+// the status to show on the public dashboard. Rejected credentials are our
+// misconfiguration rather than a vendor outage, so they're logged as an
+// error naming the owning team, for the error log sink to alert on, and the
+// vendor is shown as unknown until they're fixed.
+func (h *Handler) publishVendor(ctx context.Context, integration, owner string, res *healthchecks.ServiceResponse) string {
+	c := &status.CredentialHealth{
+		Integration: integration,
+		Owner:       owner,
+		Status:      res.Credentials,
+		Detail:      res.Detail,
+		CheckedAt:   time.Now().UTC(),
+	}
+	if err := h.Statuses.UpdateCredentialHealth(ctx, c); err != nil {
+		clog.Errorf("unable to update credential health for %s: %v", integration, err)
+	}
+
+	if res.Status != healthchecks.StatusMisconfigured {
+		return res.Status
+	}
+
+	clog.Errorw("vendor rejected our credentials", "integration", integration, "owner", owner, "detail", res.Detail)
+	return healthchecks.StatusUnknown
+}
+
 func (h *Handler) AllChecks(ctx context.Context, bucket string) error {
 	statuses := []*StatusLog{}
 	for {
@@ -40,54 +71,58 @@ func (h *Handler) AllChecks(ctx context.Context, bucket string) error {
 			clog.Fatalf("Error retrieving platform status", err)
 		}
 
-		statuses = append(statuses, &StatusLog{Service: "platform-api", Status: platformStatus.Status})
+		statuses = append(statuses, &StatusLog{Service: "platform-api", Status: platformStatus.State()})
 
-		if err := h.Statuses.UpdateStatus(ctx, "platform", platformStatus.Status); err != nil {
+		if err := h.Statuses.UpdateStatus(ctx, "platform", platformStatus.State()); err != nil {
 			clog.Fatalf("Error updating platform status", err)
 		}
 
+		h.updateComponents(ctx, "platform", platformStatus.Components())
+		h.recordVersion(ctx, "platform", platformStatus.BuildInfo())
+
 		fulfillmentStatus, err := h.Healthchecks.FulfillmentStatus(ctx)
 		if err != nil {
 			clog.Fatalf("Error retrieving fulfillment status", err)
 		}
 
-		statuses = append(statuses, &StatusLog{Service: "fulfillment-api", Status: fulfillmentStatus.Status})
+		statuses = append(statuses, &StatusLog{Service: "fulfillment-api", Status: fulfillmentStatus.State()})
 
-		if err := h.Statuses.UpdateStatus(ctx, "fulfillment", fulfillmentStatus.Status); err != nil {
+		if err := h.Statuses.UpdateStatus(ctx, "fulfillment", fulfillmentStatus.State()); err != nil {
 			clog.Fatalf("Error updating fulfillment status", err)
 		}
 
+		h.updateComponents(ctx, "fulfillment", fulfillmentStatus.Components())
+		h.recordVersion(ctx, "fulfillment", fulfillmentStatus.BuildInfo())
+
 		crmStatus, err := h.Healthchecks.CrmStatus(ctx)
 		if err != nil {
 			clog.Fatalf("Error retrieving crm status", err)
 		}
 
-		statuses = append(statuses, &StatusLog{Service: "crm-api", Status: crmStatus.Status})
+		statuses = append(statuses, &StatusLog{Service: "crm-api", Status: crmStatus.State()})
 
-		if err := h.Statuses.UpdateStatus(ctx, "crm", crmStatus.Status); err != nil {
+		if err := h.Statuses.UpdateStatus(ctx, "crm", crmStatus.State()); err != nil {
 			clog.Fatalf("Error updating crm status", err)
 		}
 
+		h.updateComponents(ctx, "crm", crmStatus.Components())
+		h.recordVersion(ctx, "crm", crmStatus.BuildInfo())
+
 		studyStatus, err := h.Healthchecks.StudyStatus(ctx)
 		if err != nil {
 			clog.Fatalf("Error retrieving study status", err)
 		}
 
-		statuses = append(statuses, &StatusLog{Service: "study-service-api", Status: studyStatus.Status})
+		statuses = append(statuses, &StatusLog{Service: "study-service-api", Status: studyStatus.State()})
 
-		if err := h.Statuses.UpdateStatus(ctx, "study", studyStatus.Status); err != nil {
+		if err := h.Statuses.UpdateStatus(ctx, "study", studyStatus.State()); err != nil {
 			clog.Fatalf("Error updating study status", err)
 		}
 
-		nodeMetrics, err := h.Metrics.GetNodeMetrics(ctx)
-		if err != nil {
-			clog.Fatalf("Error retrieving node metrics", err)
-		}
+		h.updateComponents(ctx, "study", studyStatus.Components())
+		h.recordVersion(ctx, "study", studyStatus.BuildInfo())
 
-		infra := "Ok"
-		if !nodeMetrics.Healthy() {
-			infra = "high utilization"
-		}
+		infra := h.infraStatus(ctx)
 
 		statuses = append(statuses, &StatusLog{Service: "infra", Status: infra})
 
@@ -95,25 +130,41 @@ func (h *Handler) AllChecks(ctx context.Context, bucket string) error {
 			clog.Fatalf("Error updating infra status", err)
 		}
 
+		external := h.Healthchecks.ExternalConfig
+
 		hibbertStatus, err := h.Healthchecks.HibbertStatus(ctx)
 		if err != nil {
 			clog.Fatalf("Error retrieving hibbert status", err)
 		}
 
-		statuses = append(statuses, &StatusLog{Service: "hibbert-api", Status: hibbertStatus.Status})
+		hibbertPublished := h.publishVendor(ctx, "hibbert", external.HibbertOwner, hibbertStatus)
+		statuses = append(statuses, &StatusLog{Service: "hibbert-api", Status: hibbertPublished})
 
-		if err := h.Statuses.UpdateStatus(ctx, "hibbert", hibbertStatus.Status); err != nil {
+		if err := h.Statuses.UpdateStatus(ctx, "hibbert", hibbertPublished); err != nil {
 			clog.Fatalf("Error updating hibbert status", err)
 		}
 
+		stripeStatus, err := h.Healthchecks.StripeStatus(ctx)
+		if err != nil {
+			clog.Fatalf("Error retrieving stripe status", err)
+		}
+
+		stripePublished := h.publishVendor(ctx, "stripe", external.StripeOwner, stripeStatus)
+		statuses = append(statuses, &StatusLog{Service: "stripe-api", Status: stripePublished})
+
+		if err := h.Statuses.UpdateStatus(ctx, "stripe", stripePublished); err != nil {
+			clog.Fatalf("Error updating stripe status", err)
+		}
+
 		azCrmStatus, err := h.Healthchecks.AZCRMStatus(ctx)
 		if err != nil {
 			clog.Fatalf("Error retrieving az crm status", err)
 		}
 
-		statuses = append(statuses, &StatusLog{Service: "azcrm-api", Status: azCrmStatus.Status})
+		azCrmPublished := h.publishVendor(ctx, "az_crm", external.AZCRMOwner, azCrmStatus)
+		statuses = append(statuses, &StatusLog{Service: "azcrm-api", Status: azCrmPublished})
 
-		if err := h.Statuses.UpdateStatus(ctx, "az_crm", azCrmStatus.Status); err != nil {
+		if err := h.Statuses.UpdateStatus(ctx, "az_crm", azCrmPublished); err != nil {
 			clog.Fatalf("Error updating az crm status", err)
 		}
 		//TODO Ui statuses
@@ -121,32 +172,13 @@ func (h *Handler) AllChecks(ctx context.Context, bucket string) error {
 		statuses = append(statuses, &StatusLog{Service: "platform-ui", Status: "200"})
 
 		for _, status := range statuses {
-			if !strings.Contains(status.Status, "200") && !strings.Contains(status.Status, "201") && !strings.Contains(status.Status, "Ok") {
-				filename := status.Service + "-logs.csv"
-				status.Timestamp = time.Now().UTC()
-
-				clog.Infow("status %s service %s ", status.Status, status.Service)
-				if err := h.Statuses.UpdateServiceDown(ctx, status.Service, status.Status, status.Timestamp); err != nil {
-					clog.Errorw("unable to insert new down status %v", err)
-					return err
-				}
-
-				statusReports, err := h.Statuses.GetServiceDown(ctx, status.Service)
-				if err != nil {
-					clog.Errorw("unable to return service report from table %v", err)
-					return err
-				}
-				fmt.Println("status Reports ", &statusReports[0])
-				csvContent, err := gocsv.MarshalString(&statusReports)
-				if err != nil {
-					clog.Errorw("unable to marshal csv string %v", err)
-					return err
-				}
-
-				if err := h.Write(ctx, csvContent, bucket, filename); err != nil {
-					clog.Errorf("unable to write data to bucket %s, object %s:  %v", bucket, filename, err)
+			switch {
+			case isDown(status.Status):
+				if err := h.logDown(ctx, bucket, status); err != nil {
 					return err
 				}
+			case isImpaired(status.Status):
+				clog.Infow("service impaired", "service", status.Service, "status", status.Status)
 			}
 		}
 
@@ -155,6 +187,57 @@ func (h *Handler) AllChecks(ctx context.Context, bucket string) error {
 
 }
 
+// impaired statuses are shown on the dashboard but aren't outages, a
+// degraded service still works, an unknown one couldn't be checked and
+// infra with no data has nothing to judge it by.
+var impaired = map[string]bool{
+	healthchecks.StatusDegraded: true,
+	healthchecks.StatusUnknown:  true,
+	metrics.StatusNoData:        true,
+}
+
+func isImpaired(status string) bool {
+	return impaired[status]
+}
+
+func isDown(status string) bool {
+	if impaired[status] {
+		return false
+	}
+	return !strings.Contains(status, "200") && !strings.Contains(status, "201") && !strings.Contains(status, "Ok")
+}
+
+// logDown records a service that isn't healthy in the outage table and
+// rewrites the service's report in the bucket.
+func (h *Handler) logDown(ctx context.Context, bucket string, status *StatusLog) error {
+	filename := status.Service + "-logs.csv"
+	status.Timestamp = time.Now().UTC()
+
+	clog.Infow("status %s service %s ", status.Status, status.Service)
+	if err := h.Statuses.UpdateServiceDown(ctx, status.Service, status.Status, status.Timestamp); err != nil {
+		clog.Errorw("unable to insert new down status %v", err)
+		return err
+	}
+
+	statusReports, err := h.Statuses.GetServiceDown(ctx, status.Service)
+	if err != nil {
+		clog.Errorw("unable to return service report from table %v", err)
+		return err
+	}
+	csvContent, err := gocsv.MarshalString(&statusReports)
+	if err != nil {
+		clog.Errorw("unable to marshal csv string %v", err)
+		return err
+	}
+
+	if err := h.Write(ctx, csvContent, bucket, filename); err != nil {
+		clog.Errorf("unable to write data to bucket %s, object %s:  %v", bucket, filename, err)
+		return err
+	}
+
+	return nil
+}
+
 func (h *Handler) Write(ctx context.Context, status string, bucket, object string) error {
 	ctx, cancel := context.WithTimeout(ctx, time.Second*50)
 	defer cancel()
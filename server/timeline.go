@@ -0,0 +1,186 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/IdeaEvolver/cutter-pkg/clog"
+	"github.com/IdeaEvolver/cutter-status-dashboard/healthchecks"
+	"github.com/IdeaEvolver/cutter-status-dashboard/status"
+)
+
+// the timeline covers this much history unless asked for more
+const defaultTimelineWindow = 7 * 24 * time.Hour
+
+// logNames are the names the built in services go by in the outage log,
+// configured checks use the same name for both.
+var logNames = map[string]string{
+	"platform":       "platform-api",
+	"fulfillment":    "fulfillment-api",
+	"crm":            "crm-api",
+	"study":          "study-service-api",
+	"infrastructure": "infra",
+	"hibbert":        "hibbert-api",
+	"stripe":         "stripe-api",
+	"az_crm":         "azcrm-api",
+}
+
+func logName(service string) string {
+	if name, ok := logNames[service]; ok {
+		return name
+	}
+	return service
+}
+
+// down reports further apart than this belong to separate incidents
+const incidentGap = 5 * time.Minute
+
+type TimelineEvent struct {
+	Service    string                 `json:"service"`
+	Type       string                 `json:"type"`
+	Status     string                 `json:"status,omitempty"`
+	Version    *status.ServiceVersion `json:"version,omitempty"`
+	Deployment *status.Deployment     `json:"deployment,omitempty"`
+	Timestamp  time.Time              `json:"timestamp"`
+
+	// Incidents run from Timestamp to End. One starting within the deploy
+	// window after a deployment names it in PossibleCause.
+	End                    *time.Time         `json:"end,omitempty"`
+	PossiblyCausedByDeploy bool               `json:"possibly_caused_by_deploy,omitempty"`
+	PossibleCause          *status.Deployment `json:"possible_cause,omitempty"`
+}
+
+func (h *Handler) GetVersions(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	return h.Statuses.GetCurrentVersions(r.Context())
+}
+
+// GetTimeline lists a service's incidents, deployments and version changes,
+// newest first. The service can be named as in the status list or the
+// outage log. ?since= takes an RFC3339 time, the default is the last week.
+func (h *Handler) GetTimeline(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	service := serviceName(r.URL.Query().Get("service"))
+
+	since := time.Now().Add(-defaultTimelineWindow)
+	if s := r.URL.Query().Get("since"); s != "" {
+		if t, err := time.Parse(time.RFC3339, s); err == nil {
+			since = t
+		}
+	}
+
+	events := []*TimelineEvent{}
+
+	deployments, err := h.deployments(r.Context(), service)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range deployments {
+		if d.DeployedAt.Before(since) {
+			continue
+		}
+		events = append(events, &TimelineEvent{Service: service, Type: "deployment", Deployment: d, Timestamp: d.DeployedAt})
+	}
+
+	downs, err := h.Statuses.GetServiceDown(r.Context(), logName(service))
+	if err != nil {
+		return nil, err
+	}
+	for _, incident := range incidents(service, downs) {
+		if incident.End.Before(since) {
+			continue
+		}
+		if d := deployBefore(deployments, incident.Timestamp, h.DeployWindow); d != nil {
+			incident.PossiblyCausedByDeploy = true
+			incident.PossibleCause = d
+		}
+		events = append(events, incident)
+	}
+
+	versions, err := h.Statuses.GetVersionHistory(r.Context(), service)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range versions {
+		if v.FirstSeen.Before(since) {
+			continue
+		}
+		events = append(events, &TimelineEvent{Service: service, Type: "version", Version: v, Timestamp: v.FirstSeen})
+	}
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Timestamp.After(events[j].Timestamp) })
+
+	return events, nil
+}
+
+// deployments are the service's deployments filed under either its status
+// name or its outage log name.
+func (h *Handler) deployments(ctx context.Context, service string) ([]*status.Deployment, error) {
+	ret, err := h.Statuses.GetDeployments(ctx, service)
+	if err != nil {
+		return nil, err
+	}
+	if log := logName(service); log != service {
+		logged, err := h.Statuses.GetDeployments(ctx, log)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, logged...)
+	}
+	return ret, nil
+}
+
+// incidents groups down reports into incidents, a report more than
+// incidentGap after the previous one starting a new incident.
+func incidents(service string, downs []*status.StatusReport) []*TimelineEvent {
+	sorted := make([]*status.StatusReport, len(downs))
+	copy(sorted, downs)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Timestamp.Before(sorted[j].Timestamp) })
+
+	ret := []*TimelineEvent{}
+	var current *TimelineEvent
+	for _, d := range sorted {
+		if current != nil && d.Timestamp.Sub(*current.End) <= incidentGap {
+			end := d.Timestamp
+			current.End = &end
+			continue
+		}
+
+		end := d.Timestamp
+		current = &TimelineEvent{Service: service, Type: "incident", Status: d.Status, Timestamp: d.Timestamp, End: &end}
+		ret = append(ret, current)
+	}
+
+	return ret
+}
+
+// deployBefore is the latest deployment at most window before t.
+func deployBefore(deployments []*status.Deployment, t time.Time, window time.Duration) *status.Deployment {
+	var ret *status.Deployment
+	for _, d := range deployments {
+		if d.DeployedAt.After(t) || t.Sub(d.DeployedAt) > window {
+			continue
+		}
+		if ret == nil || d.DeployedAt.After(ret.DeployedAt) {
+			ret = d
+		}
+	}
+	return ret
+}
+
+func (h *Handler) recordVersion(ctx context.Context, service string, v *healthchecks.Version) {
+	if v == nil {
+		return
+	}
+
+	sv := &status.ServiceVersion{
+		Service:   service,
+		Version:   v.Version,
+		Commit:    v.Commit,
+		Build:     v.Build,
+		FirstSeen: time.Now().UTC(),
+	}
+	if err := h.Statuses.RecordVersion(ctx, sv); err != nil {
+		clog.Errorf("unable to record version for %s: %v", service, err)
+	}
+}
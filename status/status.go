@@ -3,6 +3,7 @@ package status
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/IdeaEvolver/cutter-pkg/cuterr"
@@ -13,9 +14,20 @@ type StatusStore struct {
 }
 
 type AllStatuses struct {
-	StatusId string
-	Service  string `json:"service"`
-	Status   string `json:"status"`
+	StatusId    string
+	Service     string       `json:"service"`
+	Status      string       `json:"status"`
+	DisplayName string       `json:"display_name,omitempty"`
+	Group       string       `json:"group,omitempty"`
+	Components  []*Component `json:"components,omitempty"`
+}
+
+// Component is part of a service, as broken down by its health response.
+type Component struct {
+	Component string    `json:"component"`
+	Status    string    `json:"status"`
+	Output    string    `json:"output,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 type Status struct {
@@ -35,7 +47,8 @@ func New(db *sql.DB) *StatusStore {
 }
 
 func (s *StatusStore) UpdateStatus(ctx context.Context, service, status string) error {
-	var query = `UPDATE statuses SET status = $1 WHERE service = $2`
+	var query = `INSERT INTO statuses (service, status) VALUES ($2, $1)
+		ON CONFLICT (service) DO UPDATE SET status = $1`
 
 	_, err := s.db.ExecContext(ctx, query, status, service)
 	if err != nil {
@@ -46,7 +59,7 @@ func (s *StatusStore) UpdateStatus(ctx context.Context, service, status string)
 }
 
 func (s *StatusStore) GetAllStatuses(ctx context.Context) ([]*AllStatuses, error) {
-	var query = `SELECT * FROM statuses`
+	var query = `SELECT status_id, service, status, COALESCE(display_name, ''), COALESCE(service_group, '') FROM statuses`
 
 	rows, err := s.db.QueryContext(ctx, query)
 	if err != nil {
@@ -61,15 +74,149 @@ func (s *StatusStore) GetAllStatuses(ctx context.Context) ([]*AllStatuses, error
 			&r.StatusId,
 			&r.Service,
 			&r.Status,
+			&r.DisplayName,
+			&r.Group,
 		); err != nil {
 			return nil, err
 		}
 		ret = append(ret, r)
 	}
 
+	components, err := s.getComponents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range ret {
+		r.Components = components[r.Service]
+	}
+
+	return ret, nil
+}
+
+func (s *StatusStore) getComponents(ctx context.Context) (map[string][]*Component, error) {
+	var query = `SELECT service, component, status, output, updated_at FROM components ORDER BY service, component`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, cuterr.FromDatabaseError("getComponents", err)
+	}
+	defer rows.Close()
+
+	ret := map[string][]*Component{}
+	for rows.Next() {
+		var service string
+		r := &Component{}
+		if err := rows.Scan(
+			&service,
+			&r.Component,
+			&r.Status,
+			&r.Output,
+			&r.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		ret[service] = append(ret[service], r)
+	}
 	return ret, nil
 }
 
+// UpdateComponents replaces the components recorded for service.
+func (s *StatusStore) UpdateComponents(ctx context.Context, service string, components []*Component) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return cuterr.FromDatabaseError("UpdateComponents", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM components WHERE service = $1`, service); err != nil {
+		return cuterr.FromDatabaseError("UpdateComponents", err)
+	}
+
+	var query = `INSERT INTO components (service, component, status, output, updated_at) VALUES ($1, $2, $3, $4, $5)`
+	for _, c := range components {
+		if _, err := tx.ExecContext(ctx, query, service, c.Component, c.Status, c.Output, c.UpdatedAt); err != nil {
+			return cuterr.FromDatabaseError("UpdateComponents", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return cuterr.FromDatabaseError("UpdateComponents", err)
+	}
+
+	return nil
+}
+
+// UpdateServiceInfo sets how service is labelled and grouped on the
+// dashboard.
+func (s *StatusStore) UpdateServiceInfo(ctx context.Context, service, displayName, group string) error {
+	var query = `INSERT INTO statuses (service, display_name, service_group) VALUES ($1, $2, $3)
+		ON CONFLICT (service) DO UPDATE SET display_name = $2, service_group = $3`
+
+	if _, err := s.db.ExecContext(ctx, query, service, displayName, group); err != nil {
+		return cuterr.FromDatabaseError("UpdateServiceInfo", err)
+	}
+
+	return nil
+}
+
+// MarkDiscovered records that service's check was discovered, so it can be
+// removed should its annotation go while the dashboard isn't running.
+func (s *StatusStore) MarkDiscovered(ctx context.Context, service string) error {
+	var query = `INSERT INTO statuses (service, status, discovered) VALUES ($1, 'unknown', true)
+		ON CONFLICT (service) DO UPDATE SET discovered = true`
+
+	if _, err := s.db.ExecContext(ctx, query, service); err != nil {
+		return cuterr.FromDatabaseError("MarkDiscovered", err)
+	}
+
+	return nil
+}
+
+// GetDiscoveredServices lists the services marked discovered.
+func (s *StatusStore) GetDiscoveredServices(ctx context.Context) ([]string, error) {
+	var query = `SELECT service FROM statuses WHERE discovered`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, cuterr.FromDatabaseError("GetDiscoveredServices", err)
+	}
+	defer rows.Close()
+
+	ret := []string{}
+	for rows.Next() {
+		var service string
+		if err := rows.Scan(&service); err != nil {
+			return nil, err
+		}
+		ret = append(ret, service)
+	}
+
+	return ret, rows.Err()
+}
+
+// RemoveService takes service off the dashboard. Its outage history is
+// kept.
+func (s *StatusStore) RemoveService(ctx context.Context, service string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return cuterr.FromDatabaseError("RemoveService", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM components WHERE service = $1`, service); err != nil {
+		return cuterr.FromDatabaseError("RemoveService", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM statuses WHERE service = $1`, service); err != nil {
+		return cuterr.FromDatabaseError("RemoveService", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return cuterr.FromDatabaseError("RemoveService", err)
+	}
+
+	return nil
+}
+
 // might be useful to get individual service status
 
 func (s *StatusStore) GetStatus(ctx context.Context, service string) (*Status, error) {
@@ -122,3 +269,191 @@ func (s *StatusStore) GetServiceDown(ctx context.Context, service string) ([]*St
 	}
 	return ret, nil
 }
+
+type CredentialHealth struct {
+	Integration string    `json:"integration"`
+	Owner       string    `json:"owner"`
+	Status      string    `json:"status"`
+	Detail      string    `json:"detail"`
+	CheckedAt   time.Time `json:"checked_at"`
+}
+
+func (s *StatusStore) UpdateCredentialHealth(ctx context.Context, c *CredentialHealth) error {
+	var query = `INSERT INTO credential_health (integration, owner, status, detail, checked_at) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (integration) DO UPDATE SET owner = $2, status = $3, detail = $4, checked_at = $5`
+
+	_, err := s.db.ExecContext(ctx, query, c.Integration, c.Owner, c.Status, c.Detail, c.CheckedAt)
+	if err != nil {
+		return cuterr.FromDatabaseError("UpdateCredentialHealth", err)
+	}
+
+	return nil
+}
+
+func (s *StatusStore) GetCredentialHealth(ctx context.Context) ([]*CredentialHealth, error) {
+	var query = `SELECT integration, owner, status, detail, checked_at FROM credential_health ORDER BY integration`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, cuterr.FromDatabaseError("GetCredentialHealth", err)
+	}
+	defer rows.Close()
+
+	ret := []*CredentialHealth{}
+	for rows.Next() {
+		r := &CredentialHealth{}
+		if err := rows.Scan(
+			&r.Integration,
+			&r.Owner,
+			&r.Status,
+			&r.Detail,
+			&r.CheckedAt,
+		); err != nil {
+			return nil, err
+		}
+		ret = append(ret, r)
+	}
+	return ret, nil
+}
+
+type CheckResult struct {
+	Service   string             `json:"service"`
+	Status    string             `json:"status"`
+	Message   string             `json:"message"`
+	Latency   time.Duration      `json:"latency"`
+	Metrics   map[string]float64 `json:"metrics"`
+	CheckedAt time.Time          `json:"checked_at"`
+}
+
+func (s *StatusStore) InsertCheckResult(ctx context.Context, r *CheckResult) error {
+	var query = `INSERT INTO check_results (service, status, message, latency_ms, metrics, checked_at) VALUES ($1, $2, $3, $4, $5, $6)`
+
+	metrics, err := json.Marshal(r.Metrics)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, query, r.Service, r.Status, r.Message, r.Latency.Milliseconds(), metrics, r.CheckedAt)
+	if err != nil {
+		return cuterr.FromDatabaseError("InsertCheckResult", err)
+	}
+
+	return nil
+}
+
+type ServiceVersion struct {
+	Service   string    `json:"service"`
+	Version   string    `json:"version"`
+	Commit    string    `json:"commit"`
+	Build     string    `json:"build"`
+	FirstSeen time.Time `json:"first_seen"`
+}
+
+// RecordVersion stores v unless it is already the service's latest
+// version, so the table holds when each version first appeared.
+func (s *StatusStore) RecordVersion(ctx context.Context, v *ServiceVersion) error {
+	var query = `INSERT INTO service_versions (service, version, commit, build, first_seen)
+		SELECT $1, $2, $3, $4, $5
+		WHERE NOT EXISTS (
+			SELECT 1 FROM (
+				SELECT version, commit, build FROM service_versions WHERE service = $1 ORDER BY first_seen DESC LIMIT 1
+			) latest WHERE latest.version = $2 AND latest.commit = $3 AND latest.build = $4
+		)`
+
+	_, err := s.db.ExecContext(ctx, query, v.Service, v.Version, v.Commit, v.Build, v.FirstSeen)
+	if err != nil {
+		return cuterr.FromDatabaseError("RecordVersion", err)
+	}
+
+	return nil
+}
+
+func (s *StatusStore) GetCurrentVersions(ctx context.Context) ([]*ServiceVersion, error) {
+	var query = `SELECT DISTINCT ON (service) service, version, commit, build, first_seen
+		FROM service_versions ORDER BY service, first_seen DESC`
+
+	return s.queryVersions(ctx, "GetCurrentVersions", query)
+}
+
+func (s *StatusStore) GetVersionHistory(ctx context.Context, service string) ([]*ServiceVersion, error) {
+	var query = `SELECT service, version, commit, build, first_seen
+		FROM service_versions WHERE service = $1 ORDER BY first_seen DESC`
+
+	return s.queryVersions(ctx, "GetVersionHistory", query, service)
+}
+
+func (s *StatusStore) queryVersions(ctx context.Context, op, query string, args ...interface{}) ([]*ServiceVersion, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, cuterr.FromDatabaseError(op, err)
+	}
+	defer rows.Close()
+
+	ret := []*ServiceVersion{}
+	for rows.Next() {
+		r := &ServiceVersion{}
+		if err := rows.Scan(
+			&r.Service,
+			&r.Version,
+			&r.Commit,
+			&r.Build,
+			&r.FirstSeen,
+		); err != nil {
+			return nil, err
+		}
+		ret = append(ret, r)
+	}
+	return ret, nil
+}
+
+type Deployment struct {
+	DeploymentId int       `json:"deployment_id"`
+	Service      string    `json:"service"`
+	Version      string    `json:"version"`
+	Commit       string    `json:"commit"`
+	Environment  string    `json:"environment"`
+	DeployedBy   string    `json:"deployed_by"`
+	DeployedAt   time.Time `json:"deployed_at"`
+}
+
+func (s *StatusStore) InsertDeployment(ctx context.Context, d *Deployment) error {
+	var query = `INSERT INTO deployments (service, version, commit, environment, deployed_by, deployed_at)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING deployment_id`
+
+	err := s.db.QueryRowContext(ctx, query, d.Service, d.Version, d.Commit, d.Environment, d.DeployedBy, d.DeployedAt).
+		Scan(&d.DeploymentId)
+	if err != nil {
+		return cuterr.FromDatabaseError("InsertDeployment", err)
+	}
+
+	return nil
+}
+
+func (s *StatusStore) GetDeployments(ctx context.Context, service string) ([]*Deployment, error) {
+	var query = `SELECT deployment_id, service, version, commit, environment, deployed_by, deployed_at
+		FROM deployments WHERE service = $1 ORDER BY deployed_at DESC`
+
+	rows, err := s.db.QueryContext(ctx, query, service)
+	if err != nil {
+		return nil, cuterr.FromDatabaseError("GetDeployments", err)
+	}
+	defer rows.Close()
+
+	ret := []*Deployment{}
+	for rows.Next() {
+		r := &Deployment{}
+		if err := rows.Scan(
+			&r.DeploymentId,
+			&r.Service,
+			&r.Version,
+			&r.Commit,
+			&r.Environment,
+			&r.DeployedBy,
+			&r.DeployedAt,
+		); err != nil {
+			return nil, err
+		}
+		ret = append(ret, r)
+	}
+	return ret, nil
+}